@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// prAction is what --interactive does with the selected PR.
+type prAction string
+
+const (
+	actionCheckout prAction = "checkout"
+	actionBrowse   prAction = "browse"
+	actionDiff     prAction = "diff"
+	actionComment  prAction = "comment"
+	actionApprove  prAction = "approve"
+	actionClose    prAction = "close"
+)
+
+// prActions are the entries of the action menu, in the order they are offered.
+var prActions = []huh.Option[prAction]{
+	huh.NewOption("Checkout", actionCheckout),
+	huh.NewOption("Open in browser", actionBrowse),
+	huh.NewOption("View diff", actionDiff),
+	huh.NewOption("Add comment", actionComment),
+	huh.NewOption("Approve", actionApprove),
+	huh.NewOption("Close", actionClose),
+}
+
+// chooseAction asks what to do with pr. It reports false if the menu was
+// cancelled.
+func chooseAction(pr PullRequest) (prAction, bool) {
+	action := actionCheckout
+	err := newForm(
+		huh.NewGroup(
+			huh.NewSelect[prAction]().
+				Title(fmt.Sprintf("What to do with PR #%d %s?", pr.Number, pr.Title)).
+				Options(prActions...).
+				Value(&action),
+		),
+	).Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return "", false
+	}
+	return action, true
+}
+
+// runAction runs any action but checkout, which main does with its flags.
+// body is the comment for an approval, as with --approve.
+func runAction(action prAction, pr PullRequest, body string) error {
+	switch action {
+	case actionBrowse:
+		if err := browsePR(pr, false); err != nil {
+			return err
+		}
+		audit.record(pr, "view")
+	case actionDiff:
+		return showDiff(pr)
+	case actionComment:
+		return commentPR(pr)
+	case actionApprove:
+		return approvePRs([]PullRequest{pr}, body)
+	case actionClose:
+		return closePR(pr)
+	}
+	return nil
+}
+
+// showDiff shows the PR's diff with gh pr diff, in gh's pager. It isn't
+// stopped by --timeout, since the pager is open as long as the diff is read.
+func showDiff(pr PullRequest) error {
+	cmd := exec.Command("gh", "pr", "diff", strconv.Itoa(pr.Number))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := runCommand(cmd); err != nil {
+		return fmt.Errorf("failed to show the diff of PR #%d: %w", pr.Number, err)
+	}
+	return nil
+}
+
+// commentPR asks for a comment and adds it to pr. An empty comment adds
+// nothing.
+func commentPR(pr PullRequest) error {
+	var body string
+	err := newForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title(fmt.Sprintf("Comment on PR #%d:", pr.Number)).
+				Value(&body),
+		),
+	).Run()
+	if err != nil || strings.TrimSpace(body) == "" {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	var stderrStr string
+	var execErr error
+
+	runWithProgress(fmt.Sprintf("Commenting on PR #%d...", pr.Number), func() {
+		_, stderr, err := ghExec("pr", "comment", strconv.Itoa(pr.Number), "--body", body)
+		stderrStr = stderr.String()
+		execErr = err
+	})
+
+	if execErr != nil {
+		return errors.New(strings.TrimSpace(stderrStr))
+	}
+	fmt.Printf("%s Commented on %s\n", greenStyle.Render("✓"), styleID(pr))
+	audit.record(pr, "comment")
+	return nil
+}
+
+// closePR closes pr after a confirmation. gh's output and errors are shown
+// as they are.
+func closePR(pr PullRequest) error {
+	confirmed := false
+	err := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Close PR #%d %s?", pr.Number, pr.Title)).
+				Value(&confirmed),
+		),
+	).Run()
+	if err != nil || !confirmed {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	var stdoutStr, stderrStr string
+	var execErr error
+
+	runWithProgress(fmt.Sprintf("Closing PR #%d...", pr.Number), func() {
+		stdout, stderr, err := ghExec("pr", "close", strconv.Itoa(pr.Number))
+		stdoutStr = stdout.String()
+		stderrStr = stderr.String()
+		execErr = err
+	})
+
+	if stdoutStr != "" {
+		fmt.Print(stdoutStr)
+	}
+	if stderrStr != "" {
+		fmt.Print(stderrStr)
+	}
+	if execErr != nil {
+		return fmt.Errorf("failed to close PR #%d: %w", pr.Number, execErr)
+	}
+	audit.record(pr, "close")
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// slaThreshold highlights PRs open longer than it (--sla). 0 disables it.
+var slaThreshold time.Duration
+
+// defaultStaleAfter is how long a PR goes without activity before it is
+// highlighted as stale, without --stale-after.
+const defaultStaleAfter = 30 * 24 * time.Hour
+
+// staleAfter highlights PRs without activity for longer than it
+// (--stale-after). 0 disables it.
+var staleAfter = defaultStaleAfter
+
+// ageHint explains the accepted --sla, --stale-after and --older-than values.
+const ageHint = "use a number of days (7d), weeks (2w) or a duration (36h)"
+
+// parseAge parses an age like "7d", "2w" or any Go duration like "36h".
+func parseAge(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(v * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
+// createdStyle colors the CREATED AT cell: red once the PR is open longer
+// than slaThreshold, yellow from three quarters of it, gray otherwise.
+// Without --sla, stale PRs are yellow.
+func createdStyle(pr PullRequest) lipgloss.Style {
+	if slaThreshold == 0 || pr.CreatedAt.IsZero() {
+		return staleStyle(pr)
+	}
+	switch age := time.Since(pr.CreatedAt); {
+	case age >= slaThreshold:
+		return redStyle
+	case age >= slaThreshold*3/4:
+		return yellowStyle
+	default:
+		return grayStyle
+	}
+}
+
+// updatedColumn shows when the PR last changed (--updated).
+var updatedColumn = column{
+	name:   "updated",
+	header: "UPDATED",
+	value:  func(pr PullRequest) string { return displayTime(pr.UpdatedAt) },
+	style:  staleStyle,
+	fields: []string{"updatedAt"},
+	legend: staleLegend,
+}
+
+// lastActivity is when the PR last changed, or when it was opened if gh
+// didn't say.
+func lastActivity(pr PullRequest) time.Time {
+	if pr.UpdatedAt.IsZero() {
+		return pr.CreatedAt
+	}
+	return pr.UpdatedAt
+}
+
+// isStale reports whether pr has gone without activity for staleAfter.
+func isStale(pr PullRequest) bool {
+	at := lastActivity(pr)
+	return staleAfter > 0 && !at.IsZero() && time.Since(at) >= staleAfter
+}
+
+// staleStyle is yellow for stale PRs and gray otherwise.
+func staleStyle(pr PullRequest) lipgloss.Style {
+	if isStale(pr) {
+		return yellowStyle
+	}
+	return grayStyle
+}
+
+// staleLegend explains the stale highlight, if it is enabled.
+func staleLegend() []legendEntry {
+	if staleAfter == 0 {
+		return nil
+	}
+	return []legendEntry{{"2 months ago", yellowStyle, "no activity for --stale-after (default 30 days)"}}
+}
+
+// olderThan keeps the PRs opened more than age ago.
+func olderThan(prs []PullRequest, age time.Duration, now time.Time) []PullRequest {
+	var kept []PullRequest
+	for _, pr := range prs {
+		if !pr.CreatedAt.IsZero() && now.Sub(pr.CreatedAt) > age {
+			kept = append(kept, pr)
+		}
+	}
+	return kept
+}
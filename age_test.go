@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCreatedStyle(t *testing.T) {
+	now := time.Now()
+	days := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+	tests := []struct {
+		name  string
+		pr    PullRequest
+		sla   time.Duration
+		stale time.Duration
+		want  lipgloss.TerminalColor
+	}{
+		{"recent", PullRequest{CreatedAt: days(40), UpdatedAt: days(2)}, 0, defaultStaleAfter, grayStyle.GetForeground()},
+		{"stale", PullRequest{CreatedAt: days(40), UpdatedAt: days(31)}, 0, defaultStaleAfter, yellowStyle.GetForeground()},
+		{"stale by creation without an update", PullRequest{CreatedAt: days(31)}, 0, defaultStaleAfter, yellowStyle.GetForeground()},
+		{"stale turned off", PullRequest{CreatedAt: days(40), UpdatedAt: days(31)}, 0, 0, grayStyle.GetForeground()},
+		{"zero times", PullRequest{}, 0, defaultStaleAfter, grayStyle.GetForeground()},
+		{"past the sla", PullRequest{CreatedAt: days(10), UpdatedAt: days(1)}, 7 * 24 * time.Hour, defaultStaleAfter, redStyle.GetForeground()},
+		{"within the sla though stale", PullRequest{CreatedAt: days(40), UpdatedAt: days(35)}, 90 * 24 * time.Hour, defaultStaleAfter, grayStyle.GetForeground()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sla, stale := slaThreshold, staleAfter
+			slaThreshold, staleAfter = tt.sla, tt.stale
+			t.Cleanup(func() { slaThreshold, staleAfter = sla, stale })
+			if got := createdStyle(tt.pr).GetForeground(); got != tt.want {
+				t.Errorf("createdStyle().GetForeground() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// assigneesColumn shows the first assignee of the PR, followed by "+N" for
+// N more. The first login is cut rather than the count when the cell is
+// too narrow.
+var assigneesColumn = column{
+	header:   "ASSIGNEES",
+	maxWidth: 25,
+	value: func(pr PullRequest) string {
+		login, more := assigneesOf(pr)
+		return login + more
+	},
+	render: func(pr PullRequest, width int, bg lipgloss.TerminalColor) string {
+		style := magentaStyle
+		if len(pr.Assignees) == 0 {
+			style = grayStyle
+		}
+		if bg != nil {
+			style = style.Background(bg)
+		}
+		login, more := assigneesOf(pr)
+		if runewidth.StringWidth(login+more) > width {
+			login = runewidth.Truncate(login, max(0, width-runewidth.StringWidth(more)), "…")
+		}
+		return style.Render(runewidth.FillRight(login+more, width))
+	},
+	fields: []string{"assignees"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"alice +2", magentaStyle, "first assignee and two more"},
+			{"-", grayStyle, "not assigned"},
+		}
+	},
+}
+
+// assigneesOf splits what the ASSIGNEES column shows into the first login,
+// or "-" without assignees, and the " +N" counting the others.
+func assigneesOf(pr PullRequest) (string, string) {
+	switch len(pr.Assignees) {
+	case 0:
+		return "-", ""
+	case 1:
+		return pr.Assignees[0].Login, ""
+	default:
+		return pr.Assignees[0].Login, fmt.Sprintf(" +%d", len(pr.Assignees)-1)
+	}
+}
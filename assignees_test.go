@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestAssigneesColumn(t *testing.T) {
+	assigned := func(logins ...string) PullRequest {
+		var pr PullRequest
+		for _, login := range logins {
+			pr.Assignees = append(pr.Assignees, struct {
+				Login string `json:"login"`
+			}{login})
+		}
+		return pr
+	}
+	tests := []struct {
+		name  string
+		pr    PullRequest
+		width int
+		want  string
+	}{
+		{"nobody", assigned(), 9, "-        "},
+		{"one", assigned("alice"), 9, "alice    "},
+		{"many", assigned("alice", "bob", "carol"), 9, "alice +2 "},
+		{"cut before the count", assigned("alexander-the-great", "bob"), 12, "alexande… +1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assigneesColumn.render(tt.pr, tt.width, nil); got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
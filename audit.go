@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time   time.Time `json:"time"`
+	Repo   string    `json:"repo"`
+	Number int       `json:"number"`
+	Action string    `json:"action"`
+}
+
+// auditLog appends an entry per successful action to the file named by
+// $GH_PO_AUDIT_LOG. The zero value is disabled and records nothing.
+type auditLog struct {
+	path string
+	repo string
+}
+
+var audit auditLog
+
+// record appends an entry for action on pr. Write failures are reported on
+// stderr but never fail the action itself.
+func (a *auditLog) record(pr PullRequest, action string) {
+	if a.path == "" {
+		return
+	}
+	if a.repo == "" {
+		a.repo = getRepoName()
+	}
+
+	line, err := json.Marshal(auditEntry{
+		Time:   time.Now().UTC(),
+		Repo:   a.repo,
+		Number: pr.Number,
+		Action: action,
+	})
+	if err != nil {
+		return
+	}
+
+	// O_APPEND with a single write keeps lines intact when several gh po
+	// processes share the log
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to write audit log: %v\n", err)
+		return
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to write audit log: %v\n", err)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// showCoauthors adds the co-author count to the AUTHOR column
+// (--show-coauthors).
+var showCoauthors bool
+
+// coauthorFields are the gh pr list fields co-authors are read from. GitHub
+// lists Co-authored-by trailers among each commit's authors.
+var coauthorFields = []string{"commits"}
+
+// prCommit is one commit of a PR, as far as co-authors are concerned.
+type prCommit struct {
+	Authors []struct {
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	} `json:"authors"`
+}
+
+// authorColumn shows the PR author, followed by "+N" for N co-authors with
+// --show-coauthors.
+var authorColumn = column{
+	header:   "AUTHOR",
+	maxWidth: 25,
+	value: func(pr PullRequest) string {
+		if !showCoauthors {
+			return authorLogin(pr)
+		}
+		if n := len(coauthors(pr)); n > 0 {
+			return fmt.Sprintf("%s +%d", authorLogin(pr), n)
+		}
+		return authorLogin(pr)
+	},
+	style:  func(PullRequest) lipgloss.Style { return magentaStyle },
+	fields: []string{"author"},
+	legend: func() []legendEntry {
+		entries := []legendEntry{{"alice", magentaStyle, "author"}}
+		if showCoauthors {
+			entries = append(entries, legendEntry{"alice +2", magentaStyle, "author and two co-authors"})
+		}
+		return entries
+	},
+}
+
+// authorLogin returns the login of the PR author, or "ghost" like GitHub
+// does when the account has been deleted and gh reports no author.
+func authorLogin(pr PullRequest) string {
+	if pr.Author.Login == "" {
+		return "ghost"
+	}
+	return pr.Author.Login
+}
+
+// coauthors returns everyone besides the PR author who authored or
+// co-authored one of its commits, by login or by name for authors without
+// a GitHub account.
+func coauthors(pr PullRequest) []string {
+	var names []string
+	for _, c := range pr.Commits {
+		for _, a := range c.Authors {
+			name := a.Login
+			if name == "" {
+				name = a.Name
+			}
+			if name == "" || strings.EqualFold(name, pr.Author.Login) || slices.Contains(names, name) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
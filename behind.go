@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// manyBehind is the behind count from which the BEHIND column turns yellow.
+const manyBehind = 20
+
+// compareWorkers limits how many head/base comparisons run at once.
+const compareWorkers = 8
+
+// behindFields are the gh pr list fields the comparison needs.
+var behindFields = []string{"baseRefName", "headRefName", "headRepositoryOwner", "isCrossRepository"}
+
+// behindColumn shows how many commits the base has that the head lacks.
+var behindColumn = column{
+	header: "BEHIND",
+	value: func(pr PullRequest) string {
+		if pr.behind < 0 {
+			return "-"
+		}
+		return strconv.Itoa(pr.behind)
+	},
+	style: func(pr PullRequest) lipgloss.Style {
+		if pr.behind >= manyBehind {
+			return yellowStyle
+		}
+		return grayStyle
+	},
+	fields: behindFields,
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"3", grayStyle, "commits on the base missing from the head"},
+			{strconv.Itoa(manyBehind), yellowStyle, "far behind, likely needs a rebase"},
+		}
+	},
+}
+
+// markBehind sets behind on every PR by comparing its head with its base,
+// concurrently. PRs whose comparison fails get -1.
+func markBehind(prs []PullRequest) {
+	indexes := make(chan int)
+	go func() {
+		for i := range prs {
+			indexes <- i
+		}
+		close(indexes)
+	}()
+
+	var wg sync.WaitGroup
+	for range min(compareWorkers, len(prs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				prs[i].behind = behindBy(prs[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// behindBy returns how many commits the base of pr is ahead of its head,
+// or -1 if the comparison failed.
+func behindBy(pr PullRequest) int {
+	stdout, _, err := ghExec("api", fmt.Sprintf("repos/{owner}/{repo}/compare/%s...%s", pr.BaseRefName, headLabel(pr)), "-q", ".behind_by")
+	if err != nil {
+		return -1
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// behindAtMost keeps the PRs at most n commits behind their base. PRs whose
+// comparison failed are kept.
+func behindAtMost(prs []PullRequest, n int) []PullRequest {
+	var kept []PullRequest
+	for _, pr := range prs {
+		if pr.behind <= n {
+			kept = append(kept, pr)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// branchTemplateFields are the gh pr list fields --branch-template needs
+// beyond baseFields.
+var branchTemplateFields = []string{"author", "baseRefName"}
+
+// branchTemplateData is what a --branch-template can refer to, e.g.
+// "review/{{.Author}}/{{.Branch}}".
+type branchTemplateData struct {
+	Number int
+	Title  string
+	Author string
+	Branch string
+	Base   string
+}
+
+// parseBranchTemplate parses a --branch-template value.
+func parseBranchTemplate(text string) (*template.Template, error) {
+	return template.New("branch").Option("missingkey=error").Parse(text)
+}
+
+// templateBranchName renders tmpl for pr and sanitizes the result into a
+// valid git branch name.
+func templateBranchName(tmpl *template.Template, pr PullRequest) (string, error) {
+	var b strings.Builder
+	err := tmpl.Execute(&b, branchTemplateData{
+		Number: pr.Number,
+		Title:  pr.Title,
+		Author: authorLogin(pr),
+		Branch: pr.HeadRefName,
+		Base:   pr.BaseRefName,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render branch template: %w", err)
+	}
+	name := sanitizeRefName(b.String())
+	if name == "" {
+		return "", fmt.Errorf("branch template renders an empty branch name for PR #%d", pr.Number)
+	}
+	return name, nil
+}
+
+var (
+	// refIllegal matches runs of characters git does not allow in ref names
+	refIllegal = regexp.MustCompile(`[\x00-\x20\x7f~^:?*\[\\]+|\.\.+|@\{`)
+	refDashes  = regexp.MustCompile(`-{2,}`)
+)
+
+// sanitizeRefName replaces what git check-ref-format rejects with "-" and
+// drops empty path components, so "Fix: login?" becomes "Fix-login".
+func sanitizeRefName(name string) string {
+	name = refIllegal.ReplaceAllString(name, "-")
+	name = refDashes.ReplaceAllString(name, "-")
+
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		part = strings.Trim(part, "-.")
+		for strings.HasSuffix(part, ".lock") {
+			part = strings.TrimSuffix(part, ".lock")
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	name = strings.Join(parts, "/")
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+// checkBranchAvailable reports an error if a local branch called name
+// exists and does not already track pr, so checking out the same PR twice
+// keeps working.
+func checkBranchAvailable(name string, pr PullRequest) error {
+	if _, _, err := runGit("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err != nil {
+		return nil
+	}
+	merge, _, _ := runGit("config", "--get", "branch."+name+".merge")
+	switch strings.TrimSpace(merge) {
+	case "refs/pull/" + strconv.Itoa(pr.Number) + "/head", "refs/heads/" + pr.HeadRefName:
+		return nil
+	}
+	return fmt.Errorf("branch %q already exists and does not track PR #%d", name, pr.Number)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// reviewBucketThresholds split PRs awaiting my review by the time since
+// their last update. They are read from the "review_buckets" section of the
+// config file, e.g. "today: 24h".
+type reviewBucketThresholds struct {
+	// Today is the age up to which a PR is in the "today" bucket
+	Today time.Duration `yaml:"today"`
+	// Week is the age up to which a PR is in the "this week" bucket
+	Week time.Duration `yaml:"week"`
+}
+
+var defaultReviewBucketThresholds = reviewBucketThresholds{
+	Today: 24 * time.Hour,
+	Week:  7 * 24 * time.Hour,
+}
+
+// reviewBucketSearch limits gh pr list to PRs awaiting my review.
+const reviewBucketSearch = "review-requested:@me"
+
+// reviewBucketFields are the gh pr list fields the buckets are computed from.
+var reviewBucketFields = []string{"updatedAt"}
+
+// reviewBucket is one section of the --review-buckets picker.
+type reviewBucket struct {
+	name string
+	prs  []PullRequest
+}
+
+// bucketByStaleness groups prs into "older", "this week" and "today",
+// oldest first, so the queue can be worked through from the bottom. PRs
+// without an update time count as older. Empty buckets are dropped.
+func bucketByStaleness(prs []PullRequest, th reviewBucketThresholds, now time.Time) []reviewBucket {
+	buckets := []reviewBucket{{name: "older"}, {name: "this week"}, {name: "today"}}
+
+	sorted := append([]PullRequest{}, prs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].UpdatedAt.Before(sorted[j].UpdatedAt)
+	})
+	for _, pr := range sorted {
+		age := now.Sub(pr.UpdatedAt)
+		switch {
+		case pr.UpdatedAt.IsZero() || age > th.Week:
+			buckets[0].prs = append(buckets[0].prs, pr)
+		case age > th.Today:
+			buckets[1].prs = append(buckets[1].prs, pr)
+		default:
+			buckets[2].prs = append(buckets[2].prs, pr)
+		}
+	}
+
+	nonEmpty := buckets[:0]
+	for _, b := range buckets {
+		if len(b.prs) > 0 {
+			nonEmpty = append(nonEmpty, b)
+		}
+	}
+	return nonEmpty
+}
+
+// errBucketHeader rejects selecting a section header in the bucket picker.
+var errBucketHeader = errors.New("select a pull request, not a section header")
+
+// selectPRInBuckets is selectPR with the PRs shown under a header per
+// bucket. Headers are options too, with the value -1, since huh has no
+// unselectable rows; choosing one is rejected by validation.
+func selectPRInBuckets(buckets []reviewBucket, cols []column) (PullRequest, bool) {
+	var prs []PullRequest
+	for _, b := range buckets {
+		prs = append(prs, b.prs...)
+	}
+	widths := columnWidths(prs, cols)
+
+	var options []huh.Option[int]
+	i := 0
+	for _, b := range buckets {
+		header := underlineStyle.Render(fmt.Sprintf("%s (%d)", b.name, len(b.prs)))
+		options = append(options, huh.NewOption(header, -1))
+		for _, pr := range b.prs {
+			label := formatPR(pr, cols, widths)
+			if zebraRows && i%2 == 1 {
+				label = formatRow(pr, cols, widths, zebraBackground)
+			}
+			options = append(options, huh.NewOption(label, i))
+			i++
+		}
+	}
+
+	// Start on the first PR rather than on a header. Like in selectPR, the
+	// value must be bound before the options for the cursor to follow it.
+	selected := 0
+	field := huh.NewSelect[int]().
+		Title("Select a PR to review:").
+		Description(buildHeader(cols, widths)).
+		Value(&selected).
+		Options(options...).
+		Validate(func(i int) error {
+			if i < 0 {
+				return errBucketHeader
+			}
+			return nil
+		})
+	form := newForm(huh.NewGroup(field))
+
+	filter := &selectFilter{field: field, value: &selected, options: options}
+	if err := runPicker(form, buildLegend(cols), field, prs, cols, filter); err != nil {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return PullRequest{}, false
+	}
+
+	return prs[selected], true
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checksState is the combined outcome of a PR's checks.
+type checksState int
+
+const (
+	checksNone checksState = iota
+	checksPassing
+	checksPending
+	checksFailing
+)
+
+// failedConclusions are the check run conclusions and commit status states
+// that fail a PR's checks.
+var failedConclusions = []string{"FAILURE", "ERROR", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE"}
+
+// pendingStates are the commit status states of checks still running.
+var pendingStates = []string{"PENDING", "EXPECTED"}
+
+// checksColumn shows whether the checks of the head commit pass.
+var checksColumn = column{
+	header: "CI",
+	value: func(pr PullRequest) string {
+		switch checksOf(pr.StatusCheckRollup) {
+		case checksPassing:
+			return "✓"
+		case checksFailing:
+			return "✗"
+		case checksPending:
+			return "•"
+		default:
+			return "-"
+		}
+	},
+	style: func(pr PullRequest) lipgloss.Style {
+		switch checksOf(pr.StatusCheckRollup) {
+		case checksPassing:
+			return greenStyle
+		case checksFailing:
+			return redStyle
+		case checksPending:
+			return yellowStyle
+		default:
+			return grayStyle
+		}
+	},
+	fields: []string{"statusCheckRollup"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"✓", greenStyle, "all checks passed"},
+			{"✗", redStyle, "a check failed"},
+			{"•", yellowStyle, "checks are still running"},
+			{"-", grayStyle, "no checks"},
+		}
+	},
+}
+
+// checksOf combines the rollup into one state: failing if any check
+// failed, pending if any is still running, passing otherwise.
+func checksOf(runs []checkRun) checksState {
+	if len(runs) == 0 {
+		return checksNone
+	}
+	state := checksPassing
+	for _, r := range runs {
+		switch {
+		case slices.Contains(failedConclusions, r.Conclusion) || slices.Contains(failedConclusions, r.State):
+			return checksFailing
+		case slices.Contains(pendingStates, r.State) || r.Status != "" && r.Status != "COMPLETED":
+			state = checksPending
+		}
+	}
+	return state
+}
+
+// showChecks runs gh pr checks for pr with its output shown as it comes.
+// gh exits non-zero when a check failed or is pending; the returned
+// *exec.ExitError carries its exit code.
+func showChecks(pr PullRequest) error {
+	cmd, run := ghCommand(ghTimeout, "pr", "checks", strconv.Itoa(pr.Number))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return run()
+}
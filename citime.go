@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checkRun is one entry of a PR's statusCheckRollup: a check run, or a
+// commit status, which has a State instead of Status and Conclusion. Commit
+// statuses have no completion time; they count as finished when they
+// started.
+type checkRun struct {
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	State       string    `json:"state"`
+}
+
+// ciTimeColumn shows when the checks of the head commit last ran and how
+// long they took.
+var ciTimeColumn = column{
+	header: "CI TIME",
+	value:  func(pr PullRequest) string { return ciTime(pr.StatusCheckRollup, time.Now()) },
+	style:  func(PullRequest) lipgloss.Style { return grayStyle },
+	fields: []string{"statusCheckRollup"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"ran 5m ago, 3m12s", grayStyle, "checks finished 5 minutes ago after running for 3m12s"},
+			{"running 2m", grayStyle, "checks started 2 minutes ago and are still running"},
+		}
+	},
+}
+
+// ciTime summarizes the check runs as "ran 5m ago, 3m12s", measured from
+// the first start to the last completion, or "-" without checks.
+func ciTime(runs []checkRun, now time.Time) string {
+	var start, end time.Time
+	running := false
+	for _, r := range runs {
+		if r.StartedAt.IsZero() {
+			continue
+		}
+		if start.IsZero() || r.StartedAt.Before(start) {
+			start = r.StartedAt
+		}
+		done := r.CompletedAt
+		if done.IsZero() {
+			running = true
+			done = r.StartedAt
+		}
+		if done.After(end) {
+			end = done
+		}
+	}
+
+	switch {
+	case start.IsZero():
+		return "-"
+	case running:
+		return "running " + shortDuration(now.Sub(start))
+	default:
+		return fmt.Sprintf("ran %s ago, %s", shortDuration(now.Sub(end)), end.Sub(start).Round(time.Second))
+	}
+}
+
+// shortDuration renders d in its largest whole unit, e.g. "5m" or "3d".
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/browser"
+)
+
+// compareFields are the gh pr list fields the compare URL is built from.
+var compareFields = []string{"baseRefName", "headRefName", "isCrossRepository", "headRepositoryOwner"}
+
+// compareURL returns GitHub's compare view of the PR's base and head, e.g.
+// "https://github.com/owner/repo/compare/main...contributor:fix" for a fork.
+func compareURL(pr PullRequest) string {
+	repoURL := strings.TrimSuffix(pr.URL, "/pull/"+strconv.Itoa(pr.Number))
+	return repoURL + "/compare/" + pr.BaseRefName + "..." + headLabel(pr)
+}
+
+// openCompare opens the compare view of pr in the browser. gh browse only
+// opens repository paths, so the URL is opened by go-gh's browser, which
+// picks $GH_BROWSER, gh's browser setting or $BROWSER like gh does.
+func openCompare(pr PullRequest) error {
+	url := compareURL(pr)
+	debugf("open %s", url)
+	if err := browser.New("", os.Stdout, os.Stderr).Browse(url); err != nil {
+		return fmt.Errorf("failed to open the comparison of PR #%d in browser: %w", pr.Number, err)
+	}
+	return nil
+}
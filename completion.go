@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// completeCommand is the hidden subcommand the completion scripts call for
+// candidates. It takes the words after "gh po", the last being the one
+// being completed.
+const completeCommand = "__complete"
+
+// completionScripts hook into gh's own completion, which doesn't know about
+// extensions, and ask gh po for candidates after "gh po". They must be
+// loaded after gh's completion.
+var completionScripts = map[string]string{
+	"bash": `# gh po completion for bash; load after gh's own completion
+_gh_po_complete() {
+  if [[ ${COMP_WORDS[1]} == po ]]; then
+    local IFS=$'\n'
+    COMPREPLY=($(gh po __complete "${COMP_WORDS[@]:2:COMP_CWORD-2}" "${COMP_WORDS[COMP_CWORD]}" 2>/dev/null | cut -f1))
+    return
+  fi
+  __start_gh "$@"
+}
+complete -o default -F _gh_po_complete gh
+`,
+	"zsh": `# gh po completion for zsh; load after gh's own completion
+_gh_po() {
+  if [[ ${words[2]} == po ]]; then
+    local -a candidates
+    candidates=(${(f)"$(gh po __complete "${(@)words[3,CURRENT]}" 2>/dev/null)"})
+    candidates=(${candidates//:/\\:})
+    _describe 'gh po' ${candidates/$'\t'/:}
+    return
+  fi
+  _gh "$@"
+}
+compdef _gh_po gh
+`,
+	"fish": `# gh po completion for fish
+function __gh_po_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    gh po __complete $tokens[3..-1] 2>/dev/null
+end
+complete -c gh -n '__fish_seen_subcommand_from po' -f -a '(__gh_po_complete)'
+`,
+}
+
+// printCompletionScript prints the script for the shell named by the only
+// argument of "gh po completion".
+func printCompletionScript(args []string) error {
+	if len(args) != 1 || completionScripts[args[0]] == "" {
+		return fmt.Errorf("usage: gh po completion {%s}", strings.Join(sortedKeys(completionScripts), "|"))
+	}
+	fmt.Print(completionScripts[args[0]])
+	return nil
+}
+
+// complete prints the candidates for the last of words, one per line,
+// optionally followed by a tab and a description: flags for a word starting
+// with "-", the values of a flag that takes one of a few, or else the
+// numbers of the open PRs. It never shows progress or asks anything, so
+// stdout only carries candidates.
+func complete(words []string) {
+	current, prev := "", ""
+	if len(words) > 0 {
+		current = words[len(words)-1]
+	}
+	if len(words) > 1 {
+		prev = words[len(words)-2]
+	}
+
+	if strings.HasPrefix(current, "-") {
+		flag.VisitAll(func(fl *flag.Flag) {
+			name := "--" + fl.Name
+			if len(fl.Name) == 1 {
+				name = "-" + fl.Name
+			}
+			if strings.HasPrefix(name, current) {
+				fmt.Println(name)
+			}
+		})
+		return
+	}
+
+	if fl := flag.Lookup(strings.TrimLeft(prev, "-")); strings.HasPrefix(prev, "-") && fl != nil {
+		if b, ok := fl.Value.(interface{ IsBoolFlag() bool }); !ok || !b.IsBoolFlag() {
+			for _, v := range flagValues(fl.Name) {
+				if strings.HasPrefix(v, current) {
+					fmt.Println(v)
+				}
+			}
+			return
+		}
+	}
+
+	// Follow --repo and --hostname if they were typed already
+	for i, w := range words[:max(0, len(words)-1)] {
+		if (w == "--repo" || w == "-R") && i+1 < len(words)-1 {
+			os.Setenv("GH_REPO", words[i+1])
+		} else if repo, ok := strings.CutPrefix(w, "--repo="); ok {
+			os.Setenv("GH_REPO", repo)
+		} else if w == "--hostname" && i+1 < len(words)-1 {
+			os.Setenv("GH_HOST", words[i+1])
+		} else if host, ok := strings.CutPrefix(w, "--hostname="); ok {
+			os.Setenv("GH_HOST", host)
+		}
+	}
+	prs, _, err := listPRs(nil)
+	if err != nil {
+		return
+	}
+	for _, pr := range prs {
+		fmt.Printf("%d\t%s\n", pr.Number, pr.Title)
+	}
+}
+
+// flagValues are the values offered for the flag called name, if it takes
+// one of a few.
+func flagValues(name string) []string {
+	switch name {
+	case "state":
+		return prStates
+	case "sort":
+		return sortOrders
+	case "progress":
+		return progressModes
+	case "theme":
+		return sortedKeys(themes)
+	case "columns":
+		return optionalColumnNames()
+	case "truncate-order":
+		return append(columnNames(defaultColumns), optionalColumnNames()...)
+	case "copy":
+		return copyTargets
+	case "protocol":
+		return []string{"ssh", "https"}
+	case "locale":
+		return supportedLocales()
+	case "max-size":
+		return sizes
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config holds user defaults read from the config file.
+type config struct {
+	Urgency       urgencyWeights         `yaml:"urgency"`
+	ReviewBuckets reviewBucketThresholds `yaml:"review_buckets"`
+	Sizes         sizeThresholds         `yaml:"sizes"`
+
+	// ProtectedBranches are head branch patterns checkout refuses without --yes
+	ProtectedBranches []string `yaml:"protected_branches"`
+	// DateFormat is a Go time layout for the times in the table, e.g.
+	// "2006-01-02 15:04". Empty shows them relative to now.
+	DateFormat string `yaml:"date_format"`
+
+	// listDefaults at the top level apply to every repository
+	listDefaults `yaml:",inline"`
+	// Repos overrides listDefaults per repository, keyed by owner/name
+	Repos map[string]listDefaults `yaml:"repos"`
+}
+
+// listDefaults are defaults for the flags that shape the PR list. Unset
+// values leave the flag alone.
+type listDefaults struct {
+	Columns         []string `yaml:"columns"`
+	Sort            string   `yaml:"sort"`
+	Limit           int      `yaml:"limit"`
+	ReviewRequested string   `yaml:"review_requested"`
+	NotReviewedByMe *bool    `yaml:"not_reviewed_by_me"`
+}
+
+// listDefaultsFor returns the global list defaults overridden by the entry
+// for repo, if there is one.
+func (c config) listDefaultsFor(repo string) listDefaults {
+	d := c.listDefaults
+	for name, r := range c.Repos {
+		if !strings.EqualFold(name, repo) {
+			continue
+		}
+		if r.Columns != nil {
+			d.Columns = r.Columns
+		}
+		if r.Sort != "" {
+			d.Sort = r.Sort
+		}
+		if r.Limit != 0 {
+			d.Limit = r.Limit
+		}
+		if r.ReviewRequested != "" {
+			d.ReviewRequested = r.ReviewRequested
+		}
+		if r.NotReviewedByMe != nil {
+			d.NotReviewedByMe = r.NotReviewedByMe
+		}
+	}
+	return d
+}
+
+// applyListDefaults fills the flags not given on the command line from d.
+// Invalid values are reported once on stderr and skipped.
+func applyListDefaults(f *flags, d listDefaults) {
+	if !f.set["columns"] {
+		for _, name := range d.Columns {
+			if _, ok := optionalColumns[name]; !ok {
+				fmt.Fprintf(os.Stderr, "warning: ignoring unknown column %q in config file\n", name)
+				continue
+			}
+			f.columns = append(f.columns, name)
+		}
+	}
+	if !f.set["sort"] && !f.set["oldest-first"] && d.Sort != "" {
+		if validSortOrder(d.Sort) {
+			f.sort = d.Sort
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: ignoring unknown sort %q in config file\n", d.Sort)
+		}
+	}
+	if !f.set["limit"] && !f.set["L"] && d.Limit != 0 {
+		if d.Limit > 0 {
+			f.limit = d.Limit
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: ignoring limit %d in config file: must be positive\n", d.Limit)
+		}
+	}
+	if !f.set["review-requested"] && d.ReviewRequested != "" {
+		f.reviewRequested = d.ReviewRequested
+	}
+	if !f.set["not-reviewed-by-me"] && d.NotReviewedByMe != nil {
+		f.notReviewedByMe = *d.NotReviewedByMe
+	}
+}
+
+func defaultConfig() config {
+	return config{
+		Urgency:           defaultUrgencyWeights,
+		ReviewBuckets:     defaultReviewBucketThresholds,
+		Sizes:             defaultSizeThresholds,
+		ProtectedBranches: defaultProtectedBranches,
+	}
+}
+
+// configPath returns $XDG_CONFIG_HOME/gh-po/config.yml, falling back to
+// ~/.config/gh-po/config.yml.
+func configPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gh-po", "config.yml")
+}
+
+// loadConfig reads the config file on top of the built-in defaults. A
+// missing file is not an error; an unreadable or malformed one is reported
+// once on stderr and the defaults are used instead.
+func loadConfig() config {
+	cfg := defaultConfig()
+
+	path := configPath()
+	if path == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "warning: ignoring config file %s: %v\n", path, err)
+		}
+		return cfg
+	}
+
+	// Keys missing from the file keep their default values
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring malformed config file %s: %v\n", path, err)
+		return defaultConfig()
+	}
+	return cfg
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// maxConflictCheckPRs bounds the selection for --conflict-check. Every pair
+// is trial-merged, so the work grows quadratically with the selection.
+const maxConflictCheckPRs = 6
+
+// conflictCheckWorkers is the number of temporary worktrees used to
+// trial-merge pairs in parallel.
+const conflictCheckWorkers = 4
+
+// conflictRefPrefix is where PR heads are fetched to during the check.
+// The refs are deleted again once the check completes.
+const conflictRefPrefix = "refs/gh-po/conflict/"
+
+type prPair struct {
+	a, b int
+}
+
+func runConflictCheck(prs []PullRequest, cols []column) error {
+	title := fmt.Sprintf("Select PRs to check for conflicts (experimental, up to %d):", maxConflictCheckPRs)
+	selected, ok := selectPRs(prs, cols, title, maxConflictCheckPRs)
+	if !ok {
+		return nil
+	}
+	if len(selected) < 2 {
+		return errors.New("select at least two PRs to check for conflicts")
+	}
+
+	pairs := len(selected) * (len(selected) - 1) / 2
+	var conflicts map[prPair]bool
+	var checkErr error
+
+	runWithProgress(fmt.Sprintf("Trial-merging %d pairs (experimental, this may take a while)...", pairs), func() {
+		conflicts, checkErr = checkConflicts(selected)
+	})
+
+	if checkErr != nil {
+		return checkErr
+	}
+
+	printConflictMatrix(selected, conflicts)
+	return nil
+}
+
+// checkConflicts fetches the head of every PR and trial-merges each pair in
+// temporary worktrees. The returned map is keyed by PR numbers and is true
+// for pairs that conflict.
+func checkConflicts(prs []PullRequest) (map[prPair]bool, error) {
+	stdout, stderr, err := repoView("--json", "url", "-q", ".url")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository URL: %s", strings.TrimSpace(stderr.String()))
+	}
+	url := strings.TrimSpace(stdout.String())
+
+	fetchArgs := []string{"fetch", "--quiet", "--no-tags", url}
+	for _, pr := range prs {
+		fetchArgs = append(fetchArgs, fmt.Sprintf("+refs/pull/%d/head:%s%d", pr.Number, conflictRefPrefix, pr.Number))
+	}
+	defer func() {
+		for _, pr := range prs {
+			_, _, _ = runGit("update-ref", "-d", conflictRefPrefix+strconv.Itoa(pr.Number))
+		}
+	}()
+	if _, stderr, err := runGit(fetchArgs...); err != nil {
+		return nil, fmt.Errorf("failed to fetch PR heads: %s", strings.TrimSpace(stderr))
+	}
+
+	jobs := make(chan prPair)
+	go func() {
+		for i := range prs {
+			for j := i + 1; j < len(prs); j++ {
+				jobs <- prPair{prs[i].Number, prs[j].Number}
+			}
+		}
+		close(jobs)
+	}()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		conflicts = make(map[prPair]bool)
+		firstErr  error
+	)
+	workers := min(conflictCheckWorkers, len(prs)*(len(prs)-1)/2)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := mergeWorker(jobs, func(p prPair, conflict bool) {
+				mu.Lock()
+				conflicts[p] = conflict
+				mu.Unlock()
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				// Keep draining so the producer never blocks
+				for range jobs {
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return conflicts, nil
+}
+
+// mergeWorker creates its own temporary worktree and trial-merges every pair
+// it receives, reporting the outcome through report.
+func mergeWorker(jobs <-chan prPair, report func(prPair, bool)) error {
+	return withTempWorktree("gh-po-conflict-", func(dir string) error {
+		for p := range jobs {
+			conflict, err := trialMerge(dir, p)
+			if err != nil {
+				return err
+			}
+			report(p, conflict)
+		}
+		return nil
+	})
+}
+
+// withTempWorktree runs fn in a detached worktree created in a temporary
+// directory named with prefix, and removes the worktree afterwards.
+func withTempWorktree(prefix string, fn func(dir string) error) error {
+	tmp, err := os.MkdirTemp("", prefix)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "worktree")
+	if _, stderr, err := runGit("worktree", "add", "--quiet", "--detach", dir); err != nil {
+		return fmt.Errorf("failed to create temporary worktree: %s", strings.TrimSpace(stderr))
+	}
+	defer func() {
+		_, _, _ = runGit("worktree", "remove", "--force", dir)
+	}()
+
+	return fn(dir)
+}
+
+// trialMerge merges the head of p.b into the head of p.a without committing
+// and reports whether git hit a conflict.
+func trialMerge(dir string, p prPair) (bool, error) {
+	refA := conflictRefPrefix + strconv.Itoa(p.a)
+	refB := conflictRefPrefix + strconv.Itoa(p.b)
+
+	conflict, err := tryMerge(dir, refA, refB)
+	if err != nil {
+		return false, fmt.Errorf("failed to merge PR #%d into #%d: %w", p.b, p.a, err)
+	}
+	return conflict, nil
+}
+
+// tryMerge checks out ours in dir and merges theirs into it without
+// committing, reporting whether git hit a conflict. The merge is aborted
+// either way.
+func tryMerge(dir, ours, theirs string) (bool, error) {
+	if _, stderr, err := runGit("-C", dir, "checkout", "--quiet", "--force", "--detach", ours); err != nil {
+		return false, errors.New(strings.TrimSpace(stderr))
+	}
+
+	stdout, stderr, err := runGit("-C", dir, "merge", "--no-commit", "--no-ff", theirs)
+	// Clean up the merge state regardless of the outcome
+	_, _, _ = runGit("-C", dir, "merge", "--abort")
+
+	if err == nil {
+		return false, nil
+	}
+	if strings.Contains(stdout, "CONFLICT") {
+		return true, nil
+	}
+	return false, errors.New(strings.TrimSpace(stderr))
+}
+
+func printConflictMatrix(prs []PullRequest, conflicts map[prPair]bool) {
+	idWidth := 0
+	for _, pr := range prs {
+		if w := runewidth.StringWidth(fmt.Sprintf("#%d", pr.Number)); w > idWidth {
+			idWidth = w
+		}
+	}
+
+	fmt.Println(grayStyle.Render("Conflict matrix (experimental):"))
+
+	// Header row
+	row := runewidth.FillRight("", idWidth)
+	for _, pr := range prs {
+		row += "  " + styleID(pr) + strings.Repeat(" ", idWidth-runewidth.StringWidth(fmt.Sprintf("#%d", pr.Number)))
+	}
+	fmt.Println(row)
+
+	var conflicting []prPair
+	for i, a := range prs {
+		row := styleID(a) + strings.Repeat(" ", idWidth-runewidth.StringWidth(fmt.Sprintf("#%d", a.Number)))
+		for j, b := range prs {
+			var cell string
+			switch {
+			case i == j:
+				cell = grayStyle.Render(runewidth.FillRight("-", idWidth))
+			case conflictBetween(conflicts, a.Number, b.Number):
+				cell = redStyle.Render(runewidth.FillRight("✗", idWidth))
+				if i < j {
+					conflicting = append(conflicting, prPair{a.Number, b.Number})
+				}
+			default:
+				cell = greenStyle.Render(runewidth.FillRight("✓", idWidth))
+			}
+			row += "  " + cell
+		}
+		fmt.Println(row)
+	}
+
+	fmt.Println()
+	if len(conflicting) == 0 {
+		fmt.Println(greenStyle.Render("No conflicts found between the selected PRs."))
+		return
+	}
+	fmt.Println("Conflicting pairs:")
+	for _, p := range conflicting {
+		fmt.Printf("  #%d ↔ #%d\n", p.a, p.b)
+	}
+}
+
+func conflictBetween(conflicts map[prPair]bool, a, b int) bool {
+	return conflicts[prPair{a, b}] || conflicts[prPair{b, a}]
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+)
+
+// copyTargets are the accepted --copy values.
+var copyTargets = []string{"branch", "url"}
+
+// copyPR copies the head branch or the URL of pr to the clipboard. Without
+// a clipboard, e.g. on CI, the value is printed instead with a warning.
+func copyPR(pr PullRequest, target string) {
+	value := pr.HeadRefName
+	if target == "url" {
+		value = pr.URL
+	}
+	if err := clipboard.WriteAll(value); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: cannot copy to the clipboard (%v); printing the %s instead\n", err, target)
+		fmt.Println(value)
+		return
+	}
+	fmt.Printf("%s Copied %s\n", greenStyle.Render("✓"), value)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// debugLog logs the gh and git commands gh po runs, and how long they took,
+// to stderr (--debug). It is nil otherwise, which makes logging a no-op.
+var debugLog *log.Logger
+
+// enableDebug starts logging to stderr.
+func enableDebug() {
+	debugLog = log.New(os.Stderr, "[debug] ", log.Ltime|log.Lmicroseconds)
+}
+
+// debugf logs a line with --debug.
+func debugf(format string, args ...any) {
+	if debugLog != nil {
+		debugLog.Printf(format, args...)
+	}
+}
+
+// noTrace is what traceCommand returns without --debug.
+func noTrace(error) {}
+
+// traceCommand logs the command name with args before it runs. The
+// returned function logs how long it took and how it ended:
+//
+//	done := traceCommand("git", args...)
+//	err := cmd.Run()
+//	done(err)
+func traceCommand(name string, args ...string) func(error) {
+	if debugLog == nil {
+		return noTrace
+	}
+	command := shellJoin(append([]string{name}, args...))
+	debugLog.Printf("run %s", command)
+	start := time.Now()
+	return func(err error) {
+		if err != nil {
+			debugLog.Printf("%s failed after %s: %v", command, time.Since(start).Round(time.Millisecond), err)
+			return
+		}
+		debugLog.Printf("%s done in %s", command, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// runCommand runs cmd like cmd.Run, logged with --debug.
+func runCommand(cmd *exec.Cmd) error {
+	done := traceCommand(cmd.Args[0], cmd.Args[1:]...)
+	err := cmd.Run()
+	done(err)
+	return err
+}
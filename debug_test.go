@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestTraceCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"success", nil, []string{"run gh pr list --search \"is:draft x\"", "gh pr list --search \"is:draft x\" done in "}},
+		{"failure", errors.New("exit status 1"), []string{"run gh pr list", "failed after ", ": exit status 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			debugLog = log.New(&buf, "", 0)
+			t.Cleanup(func() { debugLog = nil })
+			traceCommand("gh", "pr", "list", "--search", "is:draft x")(tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("log = %q, want it to contain %q", buf.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestTraceCommandWithoutDebug(t *testing.T) {
+	debugLog = nil
+	// Nothing to log to; this must not panic
+	traceCommand("git", "status")(nil)
+	debugf("flags: %v", 1)
+}
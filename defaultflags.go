@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// parseDefaultFlags parses the flags in $GH_PO_DEFAULT_FLAGS into the
+// command line flag set. It runs before the real arguments are parsed, so
+// those override the defaults.
+func parseDefaultFlags() error {
+	env := os.Getenv("GH_PO_DEFAULT_FLAGS")
+	if strings.TrimSpace(env) == "" {
+		return nil
+	}
+	args, err := splitArgs(env)
+	if err != nil {
+		return err
+	}
+
+	// Report errors ourselves instead of printing the usage and exiting
+	usage := flag.Usage
+	flag.Usage = func() {}
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	flag.CommandLine.SetOutput(io.Discard)
+	defer func() {
+		flag.Usage = usage
+		flag.CommandLine.Init(os.Args[0], flag.ExitOnError)
+		flag.CommandLine.SetOutput(nil)
+	}()
+
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return err
+	}
+	if flag.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q: only flags are allowed", flag.Arg(0))
+	}
+	return nil
+}
+
+// splitArgs splits s into arguments like a POSIX shell would, honoring
+// single quotes, double quotes and backslash escapes. Nothing is expanded.
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				cur.WriteRune(runes[i])
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, errors.New("trailing backslash")
+			}
+			i++
+			cur.WriteRune(runes[i])
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
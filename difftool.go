@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// difftoolRefPrefix is where the PR's base and head are fetched to for
+// --difftool. The refs are deleted again once the tool exits.
+const difftoolRefPrefix = "refs/gh-po/difftool/"
+
+// difftoolFields are the gh pr list fields --difftool needs.
+var difftoolFields = []string{"baseRefName"}
+
+// openInDifftool fetches the base and head of pr without touching the
+// working tree and runs git difftool on base...head, so the configured
+// diff.tool shows exactly the PR's changes.
+func openInDifftool(pr PullRequest) error {
+	base := difftoolRefPrefix + strconv.Itoa(pr.Number) + "/base"
+	head := difftoolRefPrefix + strconv.Itoa(pr.Number) + "/head"
+
+	var fetchErr error
+	runWithProgress(fmt.Sprintf("Fetching PR #%d...", pr.Number), func() {
+		stdout, stderr, err := repoView("--json", "url", "-q", ".url")
+		if err != nil {
+			fetchErr = fmt.Errorf("failed to resolve repository URL: %s", strings.TrimSpace(stderr.String()))
+			return
+		}
+		url := strings.TrimSpace(stdout.String())
+		_, errOut, err := runGit("fetch", "--quiet", "--no-tags", url,
+			"+refs/heads/"+pr.BaseRefName+":"+base,
+			fmt.Sprintf("+refs/pull/%d/head:%s", pr.Number, head))
+		if err != nil {
+			fetchErr = fmt.Errorf("failed to fetch PR #%d: %s", pr.Number, strings.TrimSpace(errOut))
+		}
+	})
+	defer func() {
+		_, _, _ = runGit("update-ref", "-d", base)
+		_, _, _ = runGit("update-ref", "-d", head)
+	}()
+	if fetchErr != nil {
+		return fetchErr
+	}
+
+	// The tool may be interactive, so hand it the terminal
+	cmd := exec.Command("git", "difftool", "--no-prompt", base+"..."+head)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := runCommand(cmd); err != nil {
+		return fmt.Errorf("git difftool failed for PR #%d: %w", pr.Number, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// dryRun prints the gh commands that would check out or open a PR instead
+// of running them (--dry-run).
+var dryRun bool
+
+// printCommand prints gh with args as it could be pasted into a shell.
+func printCommand(args ...string) {
+	fmt.Println(commandLine(args...))
+}
+
+// commandLine is gh with args as it could be pasted into a shell. The
+// --repo repository is spelled out, since GH_REPO isn't set in that shell.
+func commandLine(args ...string) string {
+	if repoOverride != "" && !slices.Contains(args, "--repo") {
+		args = append(args, "--repo", repoOverride)
+	}
+	return shellJoin(append([]string{"gh"}, args...))
+}
+
+// shellJoin joins words into a command line, quoting those a shell would
+// split or expand.
+func shellJoin(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		if word == "" || strings.ContainsAny(word, " \t\n'\"\\$`*?[]{}()<>|&;#~!") {
+			word = strconv.Quote(word)
+		}
+		quoted[i] = word
+	}
+	return strings.Join(quoted, " ")
+}
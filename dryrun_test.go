@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		repo string
+		args []string
+		want string
+	}{
+		{"browse", "", browseArgs(PullRequest{Number: 12}), "gh browse 12"},
+		{"browse with --repo", "cli/cli", browseArgs(PullRequest{Number: 12}), "gh browse 12 --repo cli/cli"},
+		{"repo already given", "cli/cli", []string{"browse", "3", "--repo", "owner/other"}, "gh browse 3 --repo owner/other"},
+		{"quoted", "", []string{"pr", "checkout", "12", "--branch", "my branch"}, `gh pr checkout 12 --branch "my branch"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := repoOverride
+			repoOverride = tt.repo
+			t.Cleanup(func() { repoOverride = orig })
+			if got := commandLine(tt.args...); got != tt.want {
+				t.Errorf("commandLine(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
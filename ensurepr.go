@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// ensurePR offers to create a PR for the current branch if none of prs has
+// it as head. Detached HEADs and the default branch are left alone.
+func ensurePR(prs []PullRequest) error {
+	out, _, err := runGit("branch", "--show-current")
+	if err != nil {
+		return fmt.Errorf("failed to determine the current branch: %w", err)
+	}
+	branch := strings.TrimSpace(out)
+	if branch == "" {
+		fmt.Println(grayStyle.Render("Not on a branch, nothing to do."))
+		return nil
+	}
+
+	stdout, _, err := repoView("--json", "defaultBranchRef", "-q", ".defaultBranchRef.name")
+	if err == nil && strings.TrimSpace(stdout.String()) == branch {
+		fmt.Println(grayStyle.Render(fmt.Sprintf("On the default branch %s, nothing to do.", branch)))
+		return nil
+	}
+
+	for _, pr := range prs {
+		if pr.HeadRefName == branch && !pr.IsCrossRepository {
+			fmt.Printf("%s already has an open PR: %s  %s\n", cyanStyle.Render(branch), styleID(pr), pr.Title)
+			return nil
+		}
+	}
+
+	create := false
+	err = newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("%s has no open PR. Create one?", branch)).
+				Value(&create),
+		),
+	).Run()
+	if err != nil || !create {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	// gh pr create asks for the title and body itself, so it isn't stopped
+	// by --timeout
+	cmd := exec.Command("gh", "pr", "create", "--head", branch)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := runCommand(cmd); err != nil {
+		return fmt.Errorf("failed to create a PR for %s: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// activeFilters describes the filter flags narrowing the PR list, e.g.
+// `matching "review-requested:@me"` or "older than 7d". search is the gh
+// search query built from them. The title query is not included.
+func activeFilters(f flags, search string) []string {
+	var filters []string
+	if search != "" {
+		filters = append(filters, fmt.Sprintf("matching %q", search))
+	}
+	if f.author != "" {
+		filters = append(filters, "by "+f.author)
+	}
+	if f.assignee != "" {
+		filters = append(filters, "assigned to "+f.assignee)
+	}
+	if f.milestone != "" {
+		filters = append(filters, "in milestone "+f.milestone)
+	}
+	if len(f.labels) > 0 {
+		filters = append(filters, "labeled "+strings.Join(f.labels, ", "))
+	}
+	if f.olderThan > 0 {
+		filters = append(filters, "older than "+f.olderThanText)
+	}
+	if f.sincePR > 0 {
+		filters = append(filters, fmt.Sprintf("after #%d", f.sincePR))
+	}
+	if f.base != "" {
+		filters = append(filters, "targeting "+f.base)
+	}
+	if len(f.excludeBase) > 0 {
+		filters = append(filters, "not targeting "+strings.Join(f.excludeBase, ", "))
+	}
+	if f.maxSize != "" {
+		filters = append(filters, "of size "+f.maxSize+" or smaller")
+	}
+	switch {
+	case f.minFiles >= 0 && f.maxFiles >= 0:
+		filters = append(filters, fmt.Sprintf("changing %d to %d files", f.minFiles, f.maxFiles))
+	case f.minFiles >= 0:
+		filters = append(filters, fmt.Sprintf("changing at least %d files", f.minFiles))
+	case f.maxFiles >= 0:
+		filters = append(filters, fmt.Sprintf("changing at most %d files", f.maxFiles))
+	}
+	if f.behindAtMost >= 0 {
+		filters = append(filters, fmt.Sprintf("at most %d commits behind their base", f.behindAtMost))
+	}
+	if f.hideOrphanBase {
+		filters = append(filters, "with an existing base")
+	}
+	if f.project > 0 {
+		project := fmt.Sprintf("on project %d", f.project)
+		if f.projectStatus != "" {
+			project += fmt.Sprintf(" with status %q", f.projectStatus)
+		}
+		filters = append(filters, project)
+	}
+	return filters
+}
+
+// filterSummary renders "Showing 5 of 20 (older than 7d)" for the picker.
+func filterSummary(shown, total int, filters []string) string {
+	return fmt.Sprintf("Showing %d of %d (%s)", shown, total, strings.Join(filters, ", "))
+}
+
+// openPRCount returns the number of open PRs in repo regardless of any
+// filter, or -1 if it cannot be determined.
+func openPRCount(repo string) int {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return -1
+	}
+	stdout, _, err := ghExec("api", "graphql",
+		"-f", "query=query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { pullRequests(states: OPEN) { totalCount } } }",
+		"-F", "owner="+owner,
+		"-F", "name="+name,
+		"-q", ".data.repository.pullRequests.totalCount")
+	if err != nil {
+		return -1
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+// shortSHALength is how many characters of the head commit the SHA column
+// shows, like git's abbreviated hashes.
+const shortSHALength = 7
+
+// headBranchColumn replaces BRANCH with --wide, qualifying the head
+// branches of fork PRs with the fork's owner so that two forks' "main"
+// can be told apart.
+var headBranchColumn = column{
+	name:     "branch",
+	header:   "BRANCH",
+	maxWidth: 40,
+	value:    headLabel,
+	style: func(pr PullRequest) lipgloss.Style {
+		if pr.IsCrossRepository {
+			return magentaStyle
+		}
+		return cyanStyle
+	},
+	fields: []string{"headRepositoryOwner", "isCrossRepository"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"branch", cyanStyle, "head branch"},
+			{"user:branch", magentaStyle, "head branch of a fork"},
+		}
+	},
+}
+
+// shaColumn shows the abbreviated head commit of the PR.
+var shaColumn = column{
+	header: "SHA",
+	value: func(pr PullRequest) string {
+		return pr.HeadRefOid[:min(shortSHALength, len(pr.HeadRefOid))]
+	},
+	style:  func(PullRequest) lipgloss.Style { return grayStyle },
+	fields: []string{"headRefOid"},
+	legend: func() []legendEntry {
+		return []legendEntry{{"1a2b3c4", grayStyle, "head commit"}}
+	},
+}
+
+// headLabel is the head branch of pr, as "owner:branch" for a fork.
+func headLabel(pr PullRequest) string {
+	if pr.IsCrossRepository {
+		return pr.HeadRepositoryOwner.Login + ":" + pr.HeadRefName
+	}
+	return pr.HeadRefName
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestHeadLabel(t *testing.T) {
+	fork := PullRequest{HeadRefName: "main", IsCrossRepository: true}
+	fork.HeadRepositoryOwner.Login = "contributor"
+	tests := []struct {
+		name string
+		pr   PullRequest
+		want string
+	}{
+		{"same repository", PullRequest{HeadRefName: "fix-login"}, "fix-login"},
+		{"fork", fork, "contributor:main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := headLabel(tt.pr); got != tt.want {
+				t.Errorf("headLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShaColumn(t *testing.T) {
+	tests := []struct {
+		oid  string
+		want string
+	}{
+		{"1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b", "1a2b3c4"},
+		{"1a2b", "1a2b"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := shaColumn.value(PullRequest{HeadRefOid: tt.oid}); got != tt.want {
+			t.Errorf("shaColumn.value(%q) = %q, want %q", tt.oid, got, tt.want)
+		}
+	}
+}
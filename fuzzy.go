@@ -0,0 +1,103 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// titleMatch is a PR whose title fuzzy-matched a query.
+type titleMatch struct {
+	pr    PullRequest
+	score int
+}
+
+// fuzzyScore scores how well query matches target. Every rune of the query
+// must appear in target in order; consecutive runes and runes at the start
+// of a word score higher, and a plain substring match scores highest. It
+// returns -1 when the query does not match. Spaces in the query are ignored.
+func fuzzyScore(query, target string) int {
+	q := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0
+	}
+
+	score := 0
+	qi := 0
+	prevMatch := -2
+	for ti, r := range t {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 2
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		prevMatch = ti
+		qi++
+	}
+	if qi < len(q) {
+		return -1
+	}
+
+	if strings.Contains(strings.ToLower(target), strings.ToLower(query)) {
+		score += 2 * len(q)
+	}
+	return score
+}
+
+// matchTitles returns the PRs whose title matches query, best match first.
+// Ties keep their original order.
+func matchTitles(prs []PullRequest, query string) []titleMatch {
+	var matches []titleMatch
+	for _, pr := range prs {
+		if score := fuzzyScore(query, pr.Title); score >= 0 {
+			matches = append(matches, titleMatch{pr, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches
+}
+
+// hasClearWinner reports whether the best match stands out enough to be
+// picked without asking: it is the only match, or it scores at least half
+// again as much as the runner-up.
+func hasClearWinner(matches []titleMatch) bool {
+	switch len(matches) {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	return matches[0].score*2 >= matches[1].score*3
+}
+
+// findPR returns the PR a positional argument names exactly: a number such
+// as "1234" or "#1234", or a head branch. isNumber reports whether arg
+// looked like a number, in which case it never falls back to a title query.
+func findPR(prs []PullRequest, arg string) (pr PullRequest, isNumber, ok bool) {
+	if n, err := strconv.Atoi(strings.TrimPrefix(arg, "#")); err == nil && n > 0 {
+		for _, pr := range prs {
+			if pr.Number == n {
+				return pr, true, true
+			}
+		}
+		return PullRequest{}, true, false
+	}
+	for _, pr := range prs {
+		if pr.HeadRefName == arg {
+			return pr, false, true
+		}
+	}
+	return PullRequest{}, false, false
+}
@@ -0,0 +1,5 @@
+// Package prlist lists pull requests through gh and lays them out as the
+// aligned table rows of the picker. It holds no state of its own: the
+// layout is described by a Table and gh is run through a Runner, so both
+// can be tested without a terminal or gh.
+package prlist
@@ -0,0 +1,40 @@
+package prlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cli/go-gh/v2"
+)
+
+// Runner runs gh with args and returns its output.
+type Runner interface {
+	Run(ctx context.Context, args ...string) (stdout, stderr bytes.Buffer, err error)
+}
+
+// GH is the Runner for the real gh: $GH_PATH, or gh on the PATH. It is
+// stopped when ctx is done.
+type GH struct{}
+
+func (GH) Run(ctx context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return gh.ExecContext(ctx, args...)
+}
+
+// ParseList decodes the JSON array printed by gh pr list --json into rows
+// of T, returning the raw JSON of each row alongside.
+func ParseList[T any](data []byte) ([]T, []json.RawMessage, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse PR list: %w", err)
+	}
+
+	rows := make([]T, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, &rows[i]); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse PR list: %w", err)
+		}
+	}
+	return rows, raws, nil
+}
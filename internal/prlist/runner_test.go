@@ -0,0 +1,46 @@
+package prlist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	type pr struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	tests := []struct {
+		name    string
+		data    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "zero PRs", data: "[]", want: []int{}},
+		{name: "one PR", data: `[{"number":1,"title":"Fix bug"}]`, want: []int{1}},
+		{name: "many PRs", data: `[{"number":3},{"number":2},{"number":1}]`, want: []int{3, 2, 1}},
+		{name: "invalid JSON", data: `[{"number":`, wantErr: true},
+		{name: "wrong types", data: `[{"number":"one"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prs, raws, err := ParseList[pr]([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := make([]int, len(prs))
+			for i, p := range prs {
+				got[i] = p.Number
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseList() numbers = %v, want %v", got, tt.want)
+			}
+			if len(raws) != len(prs) {
+				t.Errorf("ParseList() returned %d raw rows for %d PRs", len(raws), len(prs))
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package prlist
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Column describes one column of a table of rows of type T.
+type Column[T any] struct {
+	// Name identifies the column in Table.TruncateOrder and DropOrder
+	Name   string
+	Header string
+	// MaxWidth caps the column width; longer values are truncated. 0 means
+	// the column grows to fit its widest value.
+	MaxWidth int
+	Value    func(row T) string
+	// Style colors the padded cell. nil renders the cell unstyled.
+	Style func(row T) lipgloss.Style
+	// Render draws the cell itself instead of Value and Style, e.g. in
+	// several colors. It must fill width and apply bg if it isn't nil.
+	Render func(row T, width int, bg lipgloss.TerminalColor) string
+}
+
+// Table describes how rows are laid out.
+type Table struct {
+	// Width is the width rows are fitted to by shrinking the columns in
+	// TruncateOrder. 0 disables fitting.
+	Width int
+	// TruncateOrder names the columns shrunk first when rows are wider
+	// than Width.
+	TruncateOrder []string
+	// DropOrder names the columns hidden, one after the other, when rows
+	// don't fit into Width even with every column shrunk.
+	DropOrder []string
+	// Borders draws box-drawing borders around the cells.
+	Borders bool
+
+	HeaderStyle lipgloss.Style
+	// SortedHeaderStyle highlights the header of the column rows are
+	// sorted by.
+	SortedHeaderStyle lipgloss.Style
+	BorderStyle       lipgloss.Style
+}
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting.
+const minColumnWidth = 8
+
+// Widths calculates the display width of each column, starting from the
+// header width and capped at the column's MaxWidth, then fits the row into
+// t.Width. A width of 0 hides the column.
+func Widths[T any](t Table, rows []T, cols []Column[T]) []int {
+	widths := make([]int, len(cols))
+	for i, col := range cols {
+		widths[i] = runewidth.StringWidth(col.Header)
+		for _, row := range rows {
+			if w := runewidth.StringWidth(col.Value(row)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		if col.MaxWidth > 0 && widths[i] > col.MaxWidth {
+			widths[i] = col.MaxWidth
+		}
+	}
+	Fit(t, widths, cols)
+	return widths
+}
+
+// Fit shrinks the columns named in t.TruncateOrder, one after the other,
+// until a row fits into t.Width. Columns are never shrunk below
+// minColumnWidth or their header; if that is not enough, the columns in
+// t.DropOrder are hidden by setting their width to 0. A row may still
+// overflow a very narrow terminal.
+func Fit[T any](t Table, widths []int, cols []Column[T]) {
+	if t.Width <= 0 {
+		return
+	}
+	total := t.rowOverhead(len(cols))
+	for _, w := range widths {
+		total += w
+	}
+	excess := total - t.Width
+	for _, name := range t.TruncateOrder {
+		if excess <= 0 {
+			return
+		}
+		for i, col := range cols {
+			if col.Name != name {
+				continue
+			}
+			floor := max(minColumnWidth, runewidth.StringWidth(col.Header))
+			shrink := min(excess, widths[i]-floor)
+			if shrink > 0 {
+				widths[i] -= shrink
+				excess -= shrink
+			}
+		}
+	}
+	for _, name := range t.DropOrder {
+		if excess <= 0 {
+			return
+		}
+		for i, col := range cols {
+			if col.Name == name && widths[i] > 0 {
+				// The cell goes, and so does one separator
+				excess -= widths[i] + t.rowOverhead(2) - t.rowOverhead(1)
+				widths[i] = 0
+			}
+		}
+	}
+}
+
+// rowOverhead is the width a row of n columns takes besides the cells: 2
+// leading spaces for the cursor and 2 between columns, or the borders and
+// their padding with Borders.
+func (t Table) rowOverhead(n int) int {
+	if t.Borders {
+		return 2 + 2 + 3*(n-1) + 2
+	}
+	return 2 + 2*(n-1)
+}
+
+// Header renders the column headers over widths, highlighting the one
+// named sorted.
+func Header[T any](t Table, cols []Column[T], widths []int, sorted string) string {
+	// Underline each label, no underline for padding
+	var labels []string
+	for i, col := range cols {
+		if widths[i] == 0 {
+			continue
+		}
+		style := t.HeaderStyle
+		if col.Name == sorted {
+			style = t.SortedHeaderStyle
+		}
+		labels = append(labels, style.Render(runewidth.FillRight(col.Header, widths[i])))
+	}
+
+	// 2 leading spaces (for cursor) + labels separated by spaces
+	if !t.Borders {
+		return "  " + strings.Join(labels, "  ")
+	}
+	return "  " + t.borderRule(widths, "┌", "┬", "┐") + "\n" +
+		"  " + t.BorderStyle.Render("│ ") + strings.Join(labels, t.BorderStyle.Render(" │ ")) + t.BorderStyle.Render(" │") + "\n" +
+		"  " + t.borderRule(widths, "├", "┼", "┤")
+}
+
+// FormatRow renders one row. A non-nil bg is applied to every cell and
+// separator individually, because a background wrapped around the whole
+// line would be cut off by the resets of the inner cell styles.
+func FormatRow[T any](t Table, row T, cols []Column[T], widths []int, bg lipgloss.TerminalColor) string {
+	var cells []string
+	for i, col := range cols {
+		if widths[i] == 0 {
+			continue
+		}
+		if col.Render != nil {
+			cells = append(cells, col.Render(row, widths[i], bg))
+			continue
+		}
+		// Truncate & pad, then color the padded cell
+		value := col.Value(row)
+		if runewidth.StringWidth(value) > widths[i] {
+			value = runewidth.Truncate(value, widths[i]-1, "…")
+		}
+		cell := runewidth.FillRight(value, widths[i])
+		switch {
+		case bg != nil && col.Style != nil:
+			cell = col.Style(row).Background(bg).Render(cell)
+		case bg != nil:
+			cell = lipgloss.NewStyle().Background(bg).Render(cell)
+		case col.Style != nil:
+			cell = col.Style(row).Render(cell)
+		}
+		cells = append(cells, cell)
+	}
+	if t.Borders {
+		style := t.BorderStyle
+		if bg != nil {
+			style = style.Background(bg)
+		}
+		return style.Render("│ ") + strings.Join(cells, style.Render(" │ ")) + style.Render(" │")
+	}
+	if bg != nil {
+		return strings.Join(cells, lipgloss.NewStyle().Background(bg).Render("  "))
+	}
+	return strings.Join(cells, "  ")
+}
+
+// borderRule draws a horizontal border over columns of the given widths,
+// e.g. "├───┼───┤". There is no closing rule below the rows, since they are
+// the picker's options and nothing can follow them.
+func (t Table) borderRule(widths []int, left, middle, right string) string {
+	var segments []string
+	for _, w := range widths {
+		if w > 0 {
+			segments = append(segments, strings.Repeat("─", w+2))
+		}
+	}
+	return t.BorderStyle.Render(left + strings.Join(segments, middle) + right)
+}
@@ -0,0 +1,128 @@
+package prlist
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+func TestMain(m *testing.M) {
+	// Compare rendered rows as plain text
+	lipgloss.SetColorProfile(termenv.Ascii)
+	os.Exit(m.Run())
+}
+
+type row struct{ id, title string }
+
+var testColumns = []Column[row]{
+	{Name: "id", Header: "ID", Value: func(r row) string { return r.id }},
+	{Name: "title", Header: "TITLE", MaxWidth: 20, Value: func(r row) string { return r.title }},
+}
+
+func TestWidths(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []row
+		want []int
+	}{
+		{"zero rows fit the headers", nil, []int{2, 5}},
+		{"one row", []row{{"#1", "Fix bug"}}, []int{2, 7}},
+		{"many rows fit the widest value", []row{{"#1", "a"}, {"#1234", "Longer title"}, {"#56", "b"}}, []int{5, 12}},
+		{"wide characters count twice", []row{{"#1", "バグ修正"}}, []int{2, 8}},
+		{"long values are capped", []row{{"#1", strings.Repeat("a", 50)}}, []int{2, 20}},
+		{"wide characters are capped by width", []row{{"#1", strings.Repeat("あ", 15)}}, []int{2, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Widths(Table{}, tt.rows, testColumns); !slices.Equal(got, tt.want) {
+				t.Errorf("Widths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		want  []int
+	}{
+		{"fits already", Table{Width: 40}, []int{5, 20}},
+		{"no width given", Table{}, []int{5, 20}},
+		{"shrinks in order", Table{Width: 20, TruncateOrder: []string{"title"}}, []int{5, 11}},
+		{"not below the floor", Table{Width: 10, TruncateOrder: []string{"title"}}, []int{5, 8}},
+		{"then drops", Table{Width: 10, TruncateOrder: []string{"title"}, DropOrder: []string{"id"}}, []int{0, 8}},
+		{"borders take room", Table{Width: 30, TruncateOrder: []string{"title"}, Borders: true}, []int{5, 16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			widths := []int{5, 20}
+			Fit(tt.table, widths, testColumns)
+			if !slices.Equal(widths, tt.want) {
+				t.Errorf("Fit() = %v, want %v", widths, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRow(t *testing.T) {
+	tests := []struct {
+		name string
+		row  row
+		want string
+	}{
+		{"padded", row{"#1", "Fix bug"}, "#1  Fix bug             "},
+		{"wide characters", row{"#1", "バグ修正"}, "#1  バグ修正            "},
+		{"truncated at the width cap", row{"#1", strings.Repeat("a", 30)}, "#1  " + strings.Repeat("a", 18) + "… "},
+		{"wide characters truncated and padded", row{"#1", strings.Repeat("あ", 15)}, "#1  " + strings.Repeat("あ", 9) + "… "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatRow(Table{}, tt.row, testColumns, []int{2, 20}, nil)
+			if got != tt.want {
+				t.Errorf("FormatRow() = %q, want %q", got, tt.want)
+			}
+			if w := runewidth.StringWidth(got); w != 24 {
+				t.Errorf("FormatRow() is %d wide, want 24", w)
+			}
+		})
+	}
+}
+
+func TestFormatRowSkipsHiddenColumns(t *testing.T) {
+	if got, want := FormatRow(Table{}, row{"#3", "Title"}, testColumns, []int{0, 5}, nil), "Title"; got != want {
+		t.Errorf("FormatRow() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatRowBorders(t *testing.T) {
+	got := FormatRow(Table{Borders: true}, row{"#3", "Title"}, testColumns, []int{2, 5}, nil)
+	if want := "│ #3 │ Title │"; got != want {
+		t.Errorf("FormatRow() = %q, want %q", got, want)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		table  Table
+		widths []int
+		want   string
+	}{
+		{"padded to the widths", Table{}, []int{4, 8}, "  ID    TITLE   "},
+		{"hidden columns", Table{}, []int{0, 5}, "  TITLE"},
+		{"borders", Table{Borders: true}, []int{2, 5}, "  ┌────┬───────┐\n  │ ID │ TITLE │\n  ├────┼───────┤"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Header(tt.table, testColumns, tt.widths, "title"); got != tt.want {
+				t.Errorf("Header() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// linkedIssue is an issue the PR will close when merged.
+type linkedIssue struct {
+	Number     int `json:"number"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+}
+
+func (i linkedIssue) nameWithOwner() string {
+	return i.Repository.Owner.Login + "/" + i.Repository.Name
+}
+
+// linkedIssues fetches the issues referenced as closed by the PR.
+func linkedIssues(pr PullRequest) ([]linkedIssue, error) {
+	stdout, stderr, err := ghExec("pr", "view", strconv.Itoa(pr.Number), "--json", "closingIssuesReferences")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch linked issues for PR #%d: %s", pr.Number, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		ClosingIssuesReferences []linkedIssue `json:"closingIssuesReferences"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse linked issues: %w", err)
+	}
+	return resp.ClosingIssuesReferences, nil
+}
+
+// openLinkedIssues opens every issue the PR closes in the browser. A PR
+// without linked issues is reported and is not an error.
+func openLinkedIssues(pr PullRequest) error {
+	issues, err := linkedIssues(pr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(issues) == 0 {
+		fmt.Println(grayStyle.Render(fmt.Sprintf("No linked issues for PR #%d.", pr.Number)))
+		return nil
+	}
+
+	for _, issue := range issues {
+		if dryRun {
+			printCommand("browse", strconv.Itoa(issue.Number), "--repo", issue.nameWithOwner())
+			continue
+		}
+		cmd, run := ghCommand(ghTimeout, "browse", strconv.Itoa(issue.Number), "--repo", issue.nameWithOwner())
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := run(); err != nil {
+			return fmt.Errorf("failed to open issue #%d in browser: %w", issue.Number, err)
+		}
+	}
+	return nil
+}
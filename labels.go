@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// prLabel is one of a PR's labels. Color is the hex color without "#".
+type prLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// labelSeparator separates the labels in the LABELS column.
+const labelSeparator = ", "
+
+// labelsColumn shows the PR's labels, each in its GitHub color. PRs without
+// labels leave the cell blank.
+var labelsColumn = column{
+	header:   "LABELS",
+	maxWidth: 30,
+	value:    labelNames,
+	render:   renderLabels,
+	fields:   []string{"labels"},
+	legend: func() []legendEntry {
+		return []legendEntry{{"label", lipgloss.NewStyle().Foreground(lipgloss.Color("#d73a4a")), "label in its color on GitHub"}}
+	},
+}
+
+// labelNames lists the names of pr's labels, e.g. "bug, needs review".
+func labelNames(pr PullRequest) string {
+	names := make([]string, len(pr.Labels))
+	for i, l := range pr.Labels {
+		names[i] = l.Name
+	}
+	return strings.Join(names, labelSeparator)
+}
+
+// renderLabels is labelNames truncated and padded to width like any other
+// cell, with each name in its label's color.
+func renderLabels(pr PullRequest, width int, bg lipgloss.TerminalColor) string {
+	plain := lipgloss.NewStyle()
+	if bg != nil {
+		plain = plain.Background(bg)
+	}
+
+	text := labelNames(pr)
+	truncated := runewidth.StringWidth(text) > width
+	if truncated {
+		text = strings.TrimSuffix(runewidth.Truncate(text, width-1, "…"), "…")
+	}
+
+	// text is a prefix of the names, so color it piece by piece
+	var b strings.Builder
+	rest := text
+	for i, l := range pr.Labels {
+		if i > 0 {
+			n := min(len(labelSeparator), len(rest))
+			b.WriteString(plain.Render(rest[:n]))
+			rest = rest[n:]
+		}
+		style := plain
+		if l.Color != "" {
+			style = style.Foreground(lipgloss.Color("#" + l.Color))
+		}
+		n := min(len(l.Name), len(rest))
+		b.WriteString(style.Render(rest[:n]))
+		rest = rest[n:]
+	}
+	if truncated {
+		text += "…"
+		b.WriteString(plain.Render("…"))
+	}
+	return b.String() + plain.Render(strings.Repeat(" ", max(0, width-runewidth.StringWidth(text))))
+}
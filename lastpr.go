@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// lastCheckoutPath returns the file remembering the PR last checked out in
+// each repository, in the user cache directory, or "" without one.
+func lastCheckoutPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-po", "last-checkout.json")
+}
+
+// loadLastCheckouts reads the PR numbers last checked out by repository
+// owner/name. A missing or corrupt file is as good as an empty one.
+func loadLastCheckouts(path string) map[string]int {
+	last := map[string]int{}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &last) != nil || last == nil {
+		return map[string]int{}
+	}
+	return last
+}
+
+// lastCheckout returns the number of the PR last checked out in repo, or 0.
+func lastCheckout(repo string) int {
+	path := lastCheckoutPath()
+	if repo == "" || path == "" {
+		return 0
+	}
+	return loadLastCheckouts(path)[repo]
+}
+
+// rememberCheckout records number as the PR last checked out in repo. It is
+// only a convenience for the picker, so failing to save is ignored.
+func rememberCheckout(repo string, number int) {
+	path := lastCheckoutPath()
+	if repo == "" || path == "" {
+		return
+	}
+	last := loadLastCheckouts(path)
+	last[repo] = number
+	data, err := json.MarshalIndent(last, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
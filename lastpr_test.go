@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLastCheckout(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("HOME", cache)
+	t.Setenv("LocalAppData", cache) // Windows
+	path := lastCheckoutPath()
+
+	if got := lastCheckout("owner/repo"); got != 0 {
+		t.Errorf("lastCheckout() without a file = %d, want 0", got)
+	}
+
+	rememberCheckout("owner/repo", 42)
+	rememberCheckout("owner/other", 7)
+	if got := lastCheckout("owner/repo"); got != 42 {
+		t.Errorf("lastCheckout() = %d, want 42", got)
+	}
+	if got := lastCheckout("owner/other"); got != 7 {
+		t.Errorf("lastCheckout() of another repository = %d, want 7", got)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCheckout("owner/repo"); got != 0 {
+		t.Errorf("lastCheckout() with a corrupt file = %d, want 0", got)
+	}
+	rememberCheckout("owner/repo", 43)
+	if got := lastCheckout("owner/repo"); got != 43 {
+		t.Errorf("lastCheckout() after replacing a corrupt file = %d, want 43", got)
+	}
+}
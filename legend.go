@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// legendEntry explains one symbol or color used in the PR table.
+type legendEntry struct {
+	sample  string
+	style   lipgloss.Style
+	meaning string
+}
+
+// buildLegend explains the symbols and colors of the given columns.
+func buildLegend(cols []column) string {
+	var entries []legendEntry
+	for _, col := range cols {
+		if col.legend != nil {
+			entries = append(entries, col.legend()...)
+		}
+	}
+
+	sampleWidth := 0
+	for _, e := range entries {
+		sampleWidth = max(sampleWidth, runewidth.StringWidth(e.sample))
+	}
+
+	lines := []string{underlineStyle.Render("LEGEND")}
+	for _, e := range entries {
+		lines = append(lines, "  "+e.style.Render(runewidth.FillRight(e.sample, sampleWidth))+"  "+e.meaning)
+	}
+	return strings.Join(lines, "\n")
+}
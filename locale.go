@@ -0,0 +1,169 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+// timeLocale describes how relative times are rendered in one language.
+// ago and fromNow fill the %s verb of each magnitude's format.
+type timeLocale struct {
+	ago        string
+	fromNow    string
+	magnitudes []humanize.RelTimeMagnitude
+}
+
+// activeLocale is the locale selected with --locale. nil means English,
+// which keeps humanize's own output.
+var activeLocale *timeLocale
+
+// timeLocales lists the supported non-English locales. Magnitudes mirror
+// humanize's defaults so every language switches units at the same points.
+var timeLocales = map[string]*timeLocale{
+	"de": {
+		ago:     "vor",
+		fromNow: "in",
+		magnitudes: []humanize.RelTimeMagnitude{
+			{D: time.Second, Format: "gerade eben", DivBy: time.Second},
+			{D: 2 * time.Second, Format: "%s 1 Sekunde", DivBy: 1},
+			{D: time.Minute, Format: "%s %d Sekunden", DivBy: time.Second},
+			{D: 2 * time.Minute, Format: "%s 1 Minute", DivBy: 1},
+			{D: time.Hour, Format: "%s %d Minuten", DivBy: time.Minute},
+			{D: 2 * time.Hour, Format: "%s 1 Stunde", DivBy: 1},
+			{D: humanize.Day, Format: "%s %d Stunden", DivBy: time.Hour},
+			{D: 2 * humanize.Day, Format: "%s 1 Tag", DivBy: 1},
+			{D: humanize.Week, Format: "%s %d Tagen", DivBy: humanize.Day},
+			{D: 2 * humanize.Week, Format: "%s 1 Woche", DivBy: 1},
+			{D: humanize.Month, Format: "%s %d Wochen", DivBy: humanize.Week},
+			{D: 2 * humanize.Month, Format: "%s 1 Monat", DivBy: 1},
+			{D: humanize.Year, Format: "%s %d Monaten", DivBy: humanize.Month},
+			{D: 18 * humanize.Month, Format: "%s 1 Jahr", DivBy: 1},
+			{D: 2 * humanize.Year, Format: "%s 2 Jahren", DivBy: 1},
+			{D: humanize.LongTime, Format: "%s %d Jahren", DivBy: humanize.Year},
+			{D: math.MaxInt64, Format: "%s sehr langer Zeit", DivBy: 1},
+		},
+	},
+	"es": {
+		ago:     "hace",
+		fromNow: "dentro de",
+		magnitudes: []humanize.RelTimeMagnitude{
+			{D: time.Second, Format: "ahora", DivBy: time.Second},
+			{D: 2 * time.Second, Format: "%s 1 segundo", DivBy: 1},
+			{D: time.Minute, Format: "%s %d segundos", DivBy: time.Second},
+			{D: 2 * time.Minute, Format: "%s 1 minuto", DivBy: 1},
+			{D: time.Hour, Format: "%s %d minutos", DivBy: time.Minute},
+			{D: 2 * time.Hour, Format: "%s 1 hora", DivBy: 1},
+			{D: humanize.Day, Format: "%s %d horas", DivBy: time.Hour},
+			{D: 2 * humanize.Day, Format: "%s 1 día", DivBy: 1},
+			{D: humanize.Week, Format: "%s %d días", DivBy: humanize.Day},
+			{D: 2 * humanize.Week, Format: "%s 1 semana", DivBy: 1},
+			{D: humanize.Month, Format: "%s %d semanas", DivBy: humanize.Week},
+			{D: 2 * humanize.Month, Format: "%s 1 mes", DivBy: 1},
+			{D: humanize.Year, Format: "%s %d meses", DivBy: humanize.Month},
+			{D: 18 * humanize.Month, Format: "%s 1 año", DivBy: 1},
+			{D: 2 * humanize.Year, Format: "%s 2 años", DivBy: 1},
+			{D: humanize.LongTime, Format: "%s %d años", DivBy: humanize.Year},
+			{D: math.MaxInt64, Format: "%s mucho tiempo", DivBy: 1},
+		},
+	},
+	"fr": {
+		ago:     "il y a",
+		fromNow: "dans",
+		magnitudes: []humanize.RelTimeMagnitude{
+			{D: time.Second, Format: "à l'instant", DivBy: time.Second},
+			{D: 2 * time.Second, Format: "%s 1 seconde", DivBy: 1},
+			{D: time.Minute, Format: "%s %d secondes", DivBy: time.Second},
+			{D: 2 * time.Minute, Format: "%s 1 minute", DivBy: 1},
+			{D: time.Hour, Format: "%s %d minutes", DivBy: time.Minute},
+			{D: 2 * time.Hour, Format: "%s 1 heure", DivBy: 1},
+			{D: humanize.Day, Format: "%s %d heures", DivBy: time.Hour},
+			{D: 2 * humanize.Day, Format: "%s 1 jour", DivBy: 1},
+			{D: humanize.Week, Format: "%s %d jours", DivBy: humanize.Day},
+			{D: 2 * humanize.Week, Format: "%s 1 semaine", DivBy: 1},
+			{D: humanize.Month, Format: "%s %d semaines", DivBy: humanize.Week},
+			{D: 2 * humanize.Month, Format: "%s 1 mois", DivBy: 1},
+			{D: humanize.Year, Format: "%s %d mois", DivBy: humanize.Month},
+			{D: 18 * humanize.Month, Format: "%s 1 an", DivBy: 1},
+			{D: 2 * humanize.Year, Format: "%s 2 ans", DivBy: 1},
+			{D: humanize.LongTime, Format: "%s %d ans", DivBy: humanize.Year},
+			{D: math.MaxInt64, Format: "%s très longtemps", DivBy: 1},
+		},
+	},
+	"ja": {
+		ago:     "前",
+		fromNow: "後",
+		magnitudes: []humanize.RelTimeMagnitude{
+			{D: time.Second, Format: "たった今", DivBy: time.Second},
+			{D: 2 * time.Second, Format: "1秒%s", DivBy: 1},
+			{D: time.Minute, Format: "%d秒%s", DivBy: time.Second},
+			{D: 2 * time.Minute, Format: "1分%s", DivBy: 1},
+			{D: time.Hour, Format: "%d分%s", DivBy: time.Minute},
+			{D: 2 * time.Hour, Format: "1時間%s", DivBy: 1},
+			{D: humanize.Day, Format: "%d時間%s", DivBy: time.Hour},
+			{D: 2 * humanize.Day, Format: "1日%s", DivBy: 1},
+			{D: humanize.Week, Format: "%d日%s", DivBy: humanize.Day},
+			{D: 2 * humanize.Week, Format: "1週間%s", DivBy: 1},
+			{D: humanize.Month, Format: "%d週間%s", DivBy: humanize.Week},
+			{D: 2 * humanize.Month, Format: "1か月%s", DivBy: 1},
+			{D: humanize.Year, Format: "%dか月%s", DivBy: humanize.Month},
+			{D: 18 * humanize.Month, Format: "1年%s", DivBy: 1},
+			{D: 2 * humanize.Year, Format: "2年%s", DivBy: 1},
+			{D: humanize.LongTime, Format: "%d年%s", DivBy: humanize.Year},
+			{D: math.MaxInt64, Format: "ずっと%s", DivBy: 1},
+		},
+	},
+}
+
+// supportedLocales returns the accepted --locale values in sorted order.
+func supportedLocales() []string {
+	names := []string{"en"}
+	for name := range timeLocales {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// setLocale selects the locale used by relativeTime and reports whether
+// the name is supported.
+func setLocale(name string) bool {
+	name = strings.ToLower(name)
+	if name == "en" {
+		activeLocale = nil
+		return true
+	}
+	l, ok := timeLocales[name]
+	if ok {
+		activeLocale = l
+	}
+	return ok
+}
+
+// dateFormat is the Go time layout times are shown in, from the config
+// file. Empty shows them relative to now.
+var dateFormat string
+
+// displayTime renders t in dateFormat in the local time zone, or relative
+// to now without one. A zero time renders as a dash either way.
+func displayTime(t time.Time) string {
+	if dateFormat == "" || t.IsZero() {
+		return relativeTime(t)
+	}
+	return t.Local().Format(dateFormat)
+}
+
+// relativeTime renders t relative to now in the active locale. A zero time,
+// e.g. a field gh returned as null, renders as a dash.
+func relativeTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	if activeLocale == nil {
+		return "about " + humanize.Time(t)
+	}
+	return humanize.CustomRelTime(t, time.Now(), activeLocale.ago, activeLocale.fromNow, activeLocale.magnitudes)
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTimeWithoutTime(t *testing.T) {
+	t.Cleanup(func() { setLocale("en") })
+	for _, name := range supportedLocales() {
+		setLocale(name)
+		if got := relativeTime(time.Time{}); got != "-" {
+			t.Errorf("relativeTime(zero) with --locale %s = %q, want %q", name, got, "-")
+		}
+	}
+}
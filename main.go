@@ -1,87 +1,828 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/cli/go-gh/v2"
-	"github.com/dustin/go-humanize"
-	"github.com/mattn/go-runewidth"
+	"github.com/charmbracelet/x/term"
+	"github.com/mfyuu/gh-po/internal/prlist"
+	"github.com/muesli/termenv"
 )
 
 type PullRequest struct {
-	Number      int       `json:"number"`
-	Title       string    `json:"title"`
-	HeadRefName string    `json:"headRefName"`
-	IsDraft     bool      `json:"isDraft"`
-	CreatedAt   time.Time `json:"createdAt"`
+	Number         int             `json:"number"`
+	Title          string          `json:"title"`
+	URL            string          `json:"url"`
+	HeadRefName    string          `json:"headRefName"`
+	HeadRefOid     string          `json:"headRefOid"`
+	IsDraft        bool            `json:"isDraft"`
+	State          string          `json:"state"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	ReviewDecision string          `json:"reviewDecision"`
+	ReviewRequests []reviewRequest `json:"reviewRequests"`
+	BaseRefName    string          `json:"baseRefName"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+	Additions      int             `json:"additions"`
+	Deletions      int             `json:"deletions"`
+	ChangedFiles   int             `json:"changedFiles"`
+	Author         struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	StatusCheckRollup []checkRun `json:"statusCheckRollup"`
+	Commits           []prCommit `json:"commits"`
+	Reviews           []prReview `json:"reviews"`
+	Labels            []prLabel  `json:"labels"`
+
+	IsCrossRepository   bool `json:"isCrossRepository"`
+	HeadRepositoryOwner struct {
+		Login string `json:"login"`
+	} `json:"headRepositoryOwner"`
+
+	// urgency is computed locally for --sort urgency and the URGENCY column
+	urgency float64
+	// baseOwner is the owner of the repository the PR targets, set for the FLOW column
+	baseOwner string
+	// projectStatus is the Status of the PR on the --project board
+	projectStatus string
+	// behind is how many commits the head lacks from the base, -1 if unknown
+	behind int
+	// orphanBase is set when the base branch no longer exists
+	orphanBase bool
+	// raw is the PR as returned by gh, holding exactly the fetched fields for --json
+	raw json.RawMessage
 }
 
-var (
-	greenStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	yellowStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
-	cyanStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
-	grayStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	underlineStyle = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("7"))
-)
+// defaultLimit is how many PRs gh pr list fetches without --limit.
+const defaultLimit = 30
+
+// diffStatFields are fetched for the diff stat printed before checkout.
+var diffStatFields = []string{"additions", "deletions"}
+
+// baseFields are always requested from gh pr list. Sorting and optional
+// columns add their own fields on top.
+var baseFields = []string{"number", "title", "url", "headRefName", "isDraft", "createdAt"}
 
 func main() {
 	f := parseFlags()
 
+	// --debug: log what is run, starting with the flags as parsed
+	if f.debug {
+		enableDebug()
+		debugf("flags: %+v", f)
+	}
+
+	// --version: print the build, then exit before anything needs a repository
+	if f.showVersion {
+		fmt.Println(versionString())
+		return
+	}
+
+	cfg := loadConfig()
+
+	// --hostname: gh and every gh command run from here talk to that host
+	// through GH_HOST. A --repo without a host is one on it.
+	if f.hostname != "" {
+		os.Setenv("GH_HOST", f.hostname)
+		if strings.Count(f.repo, "/") == 1 {
+			f.repo = f.hostname + "/" + f.repo
+		}
+	}
+
+	// --repo: gh and every gh command run from here pick the repository up
+	// from GH_REPO, like with gh's own --repo
+	if f.repo != "" {
+		repoOverride = f.repo
+		os.Setenv("GH_REPO", f.repo)
+	}
+
+	// --plain, or a pipe that no picker can be drawn on: the PRs are listed
+	// as text. --json and --print keep the picker on stderr.
+	plain := f.plain || (!f.json && !f.print && !term.IsTerminal(os.Stdout.Fd()))
+
+	// One decision for every style, huh's included: plain text renders
+	// without escape sequences
+	if f.noColor || os.Getenv("NO_COLOR") != "" || plain {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	applyTheme(themes[f.theme].withOverrides())
+	// Nothing is done with --dry-run, so there is nothing to log
+	dryRun = f.dryRun
+	forceCheckout = f.force
+	if !dryRun {
+		audit.path = os.Getenv("GH_PO_AUDIT_LOG")
+	}
+
+	// Per-repository defaults need the repository before anything else
+	var repo string
+	if len(cfg.Repos) > 0 {
+		repo = getRepoName()
+	}
+	applyListDefaults(&f, cfg.listDefaultsFor(repo))
+
+	if f.showCoauthors && !slices.Contains(f.columns, "author") {
+		f.columns = append(f.columns, "author")
+	}
+	if f.wide {
+		for _, name := range wideColumns {
+			if !slices.Contains(f.columns, name) {
+				f.columns = append(f.columns, name)
+			}
+		}
+	}
+	// A listing is an export, so its values are never cut
+	cols := tableColumns(f.columns, f.noTruncate || plain)
+	// --updated: UPDATED takes the place of CREATED AT, or follows it with --wide
+	if f.updated || f.wide {
+		i := slices.IndexFunc(cols, func(col column) bool { return col.name == "created" })
+		if f.wide {
+			cols = slices.Insert(cols, i+1, updatedColumn)
+		} else {
+			cols[i] = updatedColumn
+		}
+	}
+	// --wide: fork PRs show whose branch it is
+	if f.wide {
+		cols[slices.IndexFunc(cols, func(col column) bool { return col.name == "branch" })] = headBranchColumn
+	}
+	if f.project > 0 {
+		cols = append(cols, statusColumn)
+	}
+	if f.state != "open" {
+		cols = append(cols, stateColumn)
+	}
+	if f.truncateOrder != nil {
+		for _, name := range f.truncateOrder {
+			if !slices.Contains(columnNames(cols), name) {
+				fmt.Fprintf(os.Stderr, "invalid argument %q for \"--truncate-order\" flag: enabled columns are %s\n", name, strings.Join(columnNames(cols), ", "))
+				os.Exit(2)
+			}
+		}
+		truncateOrder = f.truncateOrder
+	}
+	if !f.noTruncate && !plain {
+		tableWidth = terminalWidth()
+	}
+	zebraRows = f.zebra
+	progressMode = f.progress
+	ghTimeout = f.timeout
+	checkoutTimeout = f.checkoutTimeout
+	ghRetries = f.retries
+	// A spinner only garbles logs, --debug's included
+	if (plain || f.debug) && !f.set["progress"] {
+		progressMode = "none"
+	}
+	pickerHints = !f.noHelp
+	mouseEnabled = !f.noMouse && mouseSupported()
+	previewPane = f.preview
+	showCoauthors = f.showCoauthors
+	slaThreshold = f.sla
+	staleAfter = f.staleAfter
+	prSizes = cfg.Sizes
+	dateFormat = cfg.DateFormat
+
+	// Keep stdout clean for the JSON output, the printed branch and the
+	// plain table
+	if f.json || f.print || plain {
+		uiOutput = os.Stderr
+	}
+	// Borders are noise where nothing is drawn, e.g. when piped, and
+	// don't belong in plain output
+	if file, ok := uiOutput.(*os.File); ok && f.borders {
+		tableBorders = term.IsTerminal(file.Fd()) && lipgloss.ColorProfile() != termenv.Ascii
+	}
+
+	// --legend: explain the symbols and colors, then exit
+	if f.legend {
+		fmt.Println(buildLegend(cols))
+		return
+	}
+
+	// --json-help: describe the --json output fields, then exit
+	if f.jsonHelp {
+		printJSONHelp(os.Stdout)
+		return
+	}
+
+	// --stash-pop: restore what --stash put away, then exit
+	if f.stashPop {
+		if err := popStash(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --summary: print repository PR stats, then exit
+	if f.summary {
+		var prs []PullRequest
+		var stderr string
+		var err error
+		runWithProgress("Fetching pull requests...", func() {
+			prs, stderr, err = listPRs(summaryFields, "--state=all", "--limit="+strconv.Itoa(summaryLimit))
+			if err == nil {
+				repo = getRepoName()
+			}
+		})
+		if err != nil {
+			fmt.Fprint(os.Stderr, stderr)
+			os.Exit(1)
+		}
+		s := summarize(repo, prs)
+		if f.json {
+			if err := writeJSON(os.Stdout, s, prettyJSON(f)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		printSummary(os.Stdout, s)
+		return
+	}
+
+	needUrgency := f.sort == "urgency" || slices.Contains(f.columns, "urgency")
+	needFlow := slices.Contains(f.columns, "flow")
+	needBaseCheck := f.hideOrphanBase || slices.Contains(f.columns, "base")
+	needBehind := f.behindAtMost >= 0 || slices.Contains(f.columns, "behind")
+	fields := columnFields(cols)
+	if f.ensurePR {
+		fields = append(fields, "isCrossRepository")
+	}
+	if f.sort == "urgency" {
+		fields = append(fields, urgencyFields...)
+	}
+	if len(f.excludeBase) > 0 {
+		fields = append(fields, "baseRefName")
+	}
+	if f.maxSize != "" {
+		fields = append(fields, diffStatFields...)
+	}
+	if f.compare {
+		fields = append(fields, compareFields...)
+	}
+	if f.minFiles >= 0 || f.maxFiles >= 0 {
+		fields = append(fields, "changedFiles")
+	}
+	if f.sort == "waiting" {
+		fields = append(fields, waitingFields...)
+	}
+	if f.sort == "updated" {
+		fields = append(fields, "updatedAt")
+	}
+	if f.branchTemplate != nil {
+		fields = append(fields, branchTemplateFields...)
+	}
+	if f.reviewBuckets {
+		fields = append(fields, reviewBucketFields...)
+	}
+	if f.hideOrphanBase {
+		fields = append(fields, orphanBaseFields...)
+	}
+	if f.difftool {
+		fields = append(fields, difftoolFields...)
+	}
+	if f.liveMergeable {
+		fields = append(fields, liveMergeableFields...)
+	}
+	if f.behindAtMost >= 0 {
+		fields = append(fields, behindFields...)
+	}
+	if f.showCoauthors {
+		fields = append(fields, coauthorFields...)
+	}
+	if f.session {
+		fields = append(fields, sessionFields...)
+	}
+	// --json prints exactly the fetched fields, so don't add any it didn't ask
+	// for. Without updatedAt, s in the picker can't sort by update time.
+	if !f.json {
+		fields = append(fields, diffStatFields...)
+		fields = append(fields, "updatedAt")
+	}
+	if !slices.Contains(fields, "updatedAt") {
+		liveSortOrders = slices.DeleteFunc(slices.Clone(liveSortOrders), func(order string) bool { return order == "updated" })
+	}
+	search := searchQuery(f)
+	filters := activeFilters(f, search)
+	var listArgs []string
+	if search != "" {
+		listArgs = append(listArgs, "--search="+search)
+	}
+	if f.state != "open" {
+		listArgs = append(listArgs, "--state="+f.state)
+	}
+	if f.author != "" {
+		listArgs = append(listArgs, "--author="+f.author)
+	}
+	if f.assignee != "" {
+		listArgs = append(listArgs, "--assignee="+f.assignee)
+	}
+	if f.milestone != "" {
+		listArgs = append(listArgs, "--milestone="+f.milestone)
+	}
+	for _, label := range f.labels {
+		listArgs = append(listArgs, "--label="+label)
+	}
+	if f.base != "" {
+		listArgs = append(listArgs, "--base="+f.base)
+	}
+	if f.limit != defaultLimit {
+		listArgs = append(listArgs, "--limit="+strconv.Itoa(f.limit))
+	}
+
 	var prs []PullRequest
 	var stderr string
 	var listErr error
+	var login string
+	var items []projectItem
+	var projectErr error
+	total := -1
 
-	_ = spinner.New().
-		Title("Fetching pull requests...").
-		Action(func() {
-			prs, stderr, listErr = listPRs()
-		}).
-		Run()
+	runWithProgress("Fetching pull requests...", func() {
+		// The list, the repository and my login are independent lookups, so
+		// only the slowest of them is waited for. Each goroutine sets its
+		// own variables, which are read after Wait.
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prs, stderr, listErr = listPRs(fields, listArgs...)
+		}()
+		// The repository is shown above the picker, so only --json skips it
+		if needFlow || f.project > 0 || !f.json {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				repo = getRepoName()
+			}()
+		}
+		if needUrgency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				login = getViewerLogin()
+			}()
+		}
+		wg.Wait()
+		if listErr != nil {
+			return
+		}
+		if needBaseCheck {
+			markOrphanBases(prs)
+		}
+		if needBehind {
+			markBehind(prs)
+		}
+		// Only the open PRs are counted, other states show no total
+		if (len(filters) > 0 || f.query != "") && repo != "" && f.state == "open" {
+			total = openPRCount(repo)
+		}
+		if f.project > 0 {
+			owner, _, _ := strings.Cut(repo, "/")
+			items, projectErr = projectItems(owner, f.project)
+		}
+	})
 
 	if listErr != nil {
+		// gh's message says what is wrong, e.g. with a --search query, as is
+		if stderr == "" {
+			stderr = fmt.Sprintf("Error: %v\n", listErr)
+		}
 		fmt.Fprint(os.Stderr, stderr)
 		os.Exit(1)
 	}
+	// gh stops at the limit without saying so
+	truncated := len(prs) == f.limit
+
+	if owner, _, ok := strings.Cut(repo, "/"); ok {
+		for i := range prs {
+			prs[i].baseOwner = owner
+		}
+	}
+
+	if projectErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", projectErr)
+		os.Exit(1)
+	}
+
+	// --ensure-pr: offer to open a PR for the current branch instead of picking
+	if f.ensurePR {
+		if err := ensurePR(prs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if total < 0 {
+		total = len(prs)
+	}
+
+	if f.hideOrphanBase {
+		prs = withoutOrphanBases(prs)
+	}
+	if f.project > 0 {
+		prs = filterByProject(prs, items, repo, f.projectStatus)
+	}
+	if f.olderThan > 0 {
+		prs = olderThan(prs, f.olderThan, time.Now())
+	}
+	if f.behindAtMost >= 0 {
+		prs = behindAtMost(prs, f.behindAtMost)
+	}
+	if f.maxSize != "" {
+		prs = atMostSize(prs, f.maxSize)
+	}
+	if f.minFiles >= 0 {
+		prs = slices.DeleteFunc(prs, func(pr PullRequest) bool { return pr.ChangedFiles < f.minFiles })
+	}
+	if f.maxFiles >= 0 {
+		prs = slices.DeleteFunc(prs, func(pr PullRequest) bool { return pr.ChangedFiles > f.maxFiles })
+	}
+	if f.sincePR > 0 {
+		prs = slices.DeleteFunc(prs, func(pr PullRequest) bool { return pr.Number <= f.sincePR })
+	}
+	if len(f.excludeBase) > 0 {
+		prs = slices.DeleteFunc(prs, func(pr PullRequest) bool { return slices.Contains(f.excludeBase, pr.BaseRefName) })
+	}
+
+	if needUrgency {
+		scoreUrgency(prs, login, cfg.Urgency, time.Now())
+	}
+	activeSort, reverseSort = f.sort, f.reverse
+	sortPRs(prs, f.sort, f.reverse)
 
 	if len(prs) == 0 {
 		// gh pr list only outputs message in TTY mode, so we print it ourselves
-		repo := getRepoName()
+		if repo == "" {
+			repo = getRepoName()
+		}
+		msg := "no " + stateAdjective(f.state) + "pull requests"
+		if len(filters) > 0 {
+			msg += " " + strings.Join(filters, " ")
+		}
 		if repo != "" {
-			fmt.Printf("no open pull requests in %s\n", repo)
+			msg += " in " + repo
+		}
+		// A named PR that isn't there is an error for scripts, and so is
+		// having no branch to print
+		if f.query != "" || f.print {
+			fmt.Fprintln(os.Stderr, msg)
+			os.Exit(1)
+		}
+		fmt.Fprintln(uiOutput, msg)
+		return
+	}
+
+	// Positional argument: a PR number or head branch is used directly,
+	// anything else narrows the list to PRs whose title fuzzy-matches it
+	var preselected *PullRequest
+	pr, isNumber, found := findPR(prs, f.query)
+	switch {
+	case f.query == "":
+	case found:
+		preselected = &pr
+	case isNumber:
+		fmt.Fprintf(os.Stderr, "no %spull request #%s among the %d listed", stateAdjective(f.state), strings.TrimPrefix(f.query, "#"), len(prs))
+		if len(filters) > 0 {
+			fmt.Fprint(os.Stderr, " "+strings.Join(filters, " "))
+		}
+		fmt.Fprintln(os.Stderr)
+		os.Exit(1)
+	default:
+		matches := matchTitles(prs, f.query)
+		if len(matches) == 0 {
+			fmt.Fprintf(os.Stderr, "no %spull request has the head branch %q or a title matching it\n", stateAdjective(f.state), f.query)
+			os.Exit(1)
+		}
+		prs = make([]PullRequest, len(matches))
+		for i, m := range matches {
+			prs[i] = m.pr
+		}
+		if hasClearWinner(matches) && !f.alwaysPrompt {
+			preselected = &prs[0]
+		}
+		filters = append(filters, fmt.Sprintf("title like %q", f.query))
+	}
+
+	// Give the picker some context, e.g. "owner/repo · 12 open PRs"
+	if preselected == nil && !f.json && !f.print && !plain && repo != "" {
+		count := fmt.Sprintf("%d %sPRs", total, stateAdjective(f.state))
+		if total == 1 {
+			count = fmt.Sprintf("1 %sPR", stateAdjective(f.state))
+		}
+		fmt.Println(grayStyle.Render(repo + " · " + count))
+	}
+	if preselected == nil && truncated {
+		fmt.Fprintln(uiOutput, grayStyle.Render(fmt.Sprintf("Only the first %d are listed; raise --limit to see more.", f.limit)))
+	}
+	if len(filters) > 0 {
+		listSummary = filterSummary(len(prs), total, filters)
+	}
+
+	// --plain: list the PRs instead of picking one. Without --plain the
+	// picker was wanted, so scripts learn that it couldn't be shown.
+	if plain && preselected == nil {
+		printTable(os.Stdout, prs, cols)
+		if !f.plain {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", errNoTerminal)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// pick returns the clear query match, or asks with the picker
+	pick := func() (PullRequest, bool) {
+		if preselected != nil {
+			return *preselected, true
+		}
+		if f.reviewBuckets {
+			return selectPRInBuckets(bucketByStaleness(prs, cfg.ReviewBuckets, time.Now()), cols)
+		}
+		return selectPR(prs, cols)
+	}
+
+	// --conflict-check: report conflicting pairs instead of checking out
+	if f.conflictCheck {
+		if len(prs) < 2 {
+			fmt.Printf("at least two %spull requests are needed to check for conflicts\n", stateAdjective(f.state))
+			return
+		}
+		if err := runConflictCheck(prs, cols); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --live-mergeable: trial-merge PRs into their base instead of checking out
+	if f.liveMergeable {
+		if err := runLiveMergeable(prs, cols); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --session: walk through the PRs awaiting my review one by one
+	if f.session {
+		if err := runSession(prs, repo, f.body); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --approve: approve the selected PR(s) instead of checking out
+	if f.approve {
+		var targets []PullRequest
+		if f.multi {
+			selected, ok := selectPRs(prs, cols, "Select PRs to approve:", 0)
+			if !ok || len(selected) == 0 {
+				return
+			}
+			targets = selected
 		} else {
-			fmt.Println("no open pull requests")
+			selected, ok := pick()
+			if !ok {
+				return
+			}
+			targets = []PullRequest{selected}
+		}
+		if err := approvePRs(targets, f.body); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --merge: merge the selected PR instead of checking out
+	if f.merge {
+		selected, ok := pick()
+		if !ok {
+			return
+		}
+		if err := mergePR(selected, f.mergeMethod, f.deleteBranch); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 		return
 	}
 
-	selected, ok := selectPR(prs)
+	// --reopen: reopen the selected closed PR instead of checking out
+	if f.reopen {
+		selected, ok := pick()
+		if !ok {
+			return
+		}
+		if err := reopenPR(selected); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	selected, ok := pick()
 	if !ok {
+		// An empty $(gh po --print) must not pass for a branch
+		if f.print {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --interactive: ask what to do with the PR; checkout goes on below
+	if f.interactive {
+		action, ok := chooseAction(selected)
+		if !ok {
+			return
+		}
+		if action != actionCheckout {
+			if err := runAction(action, selected, f.body); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// --json: print the selected PR instead of checking out
+	if f.json {
+		if err := writeJSON(os.Stdout, selected.raw, prettyJSON(f)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --print: print the branch for the shell instead of checking out
+	if f.print {
+		fmt.Println(selected.HeadRefName)
+		return
+	}
+
+	// --copy: put the branch or URL on the clipboard instead of checking out
+	if f.copy != "" {
+		copyPR(selected, f.copy)
+		return
+	}
+
+	// --checks: list the PR's checks instead of checking out, exiting like
+	// gh does so scripts can wait for green checks
+	if f.checks {
+		if err := showChecks(selected); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --tui-view: read the PR in the terminal (without checkout)
+	if f.tuiView {
+		if err := viewPRInTerminal(selected); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		audit.record(selected, "tui-view")
 		return
 	}
 
-	// --view: open in browser only (without checkout)
-	if f.view {
-		if err := browsePR(selected, false); err != nil {
+	// --difftool: review the PR's changes in the configured diff tool
+	if f.difftool {
+		if err := openInDifftool(selected); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		audit.record(selected, "difftool")
 		return
 	}
 
-	if err := checkoutPR(selected); err != nil {
+	// --view, --compare: open in browser only (without checkout)
+	if f.view || f.compare {
+		if f.view {
+			if err := browsePR(selected, false); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			audit.record(selected, "view")
+		}
+		if f.compare {
+			if err := openCompare(selected); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			audit.record(selected, "compare")
+		}
+		if f.openIssue {
+			if err := openLinkedIssues(selected); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			audit.record(selected, "open-issue")
+		}
+		return
+	}
+
+	// --protocol: make the remote use the requested protocol before fetching
+	if f.protocol != "" {
+		if err := ensureRemoteProtocol(f.protocol); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// A fork PR from its main branch would be checked out over a shared
+	// branch name, so make sure that is intended
+	if pattern, ok := protectedPattern(selected.HeadRefName, cfg.ProtectedBranches); ok && !f.yes {
+		fmt.Fprintf(os.Stderr, "%s head branch %q of PR #%d matches protected pattern %q; pass --yes to check it out anyway\n",
+			yellowStyle.Render("!"), selected.HeadRefName, selected.Number, pattern)
+		os.Exit(1)
+	}
+
+	// --branch-template: check out into a branch named after the PR
+	var branch string
+	if f.branchTemplate != nil {
+		var err error
+		if branch, err = templateBranchName(f.branchTemplate, selected); err == nil {
+			err = checkBranchAvailable(branch, selected)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// --stash: put local changes away so they don't block the checkout
+	if f.stash {
+		if err := stashBeforeCheckout(selected); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Uncommitted changes may be carried over to the PR's branch or make the
+	// checkout fail, so make sure switching is intended
+	if !f.yes && !f.stash && f.worktree == "" && !dryRun {
+		confirmed, err := confirmDirtyCheckout(selected)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+			return
+		}
+	}
+
+	// --recreate: start the PR's local branch over instead of updating it
+	if f.recreate {
+		confirmed, err := recreateBranch(selected, cmp.Or(branch, selected.HeadRefName), f.yes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+			return
+		}
+	}
+
+	// --worktree: check out into a new worktree instead of the current one
+	checkout := checkoutPR
+	if f.worktree != "" {
+		checkout = func(pr PullRequest, branch string) error {
+			return checkoutInWorktree(pr, branch, f.worktree)
+		}
+	}
+	if err := checkout(selected, branch); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	audit.record(selected, "checkout")
+	if !dryRun {
+		rememberCheckout(getRepoName(), selected.Number)
+	}
 
 	// --web: open in browser after checkout
 	if f.web {
@@ -89,161 +830,234 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		audit.record(selected, "view")
+	}
+
+	// --open-issue: open the issues the PR closes after checkout
+	if f.openIssue {
+		if err := openLinkedIssues(selected); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		audit.record(selected, "open-issue")
 	}
 }
 
-func listPRs() ([]PullRequest, string, error) {
-	stdout, stderr, err := gh.Exec("pr", "list", "--json", "number,title,headRefName,isDraft,createdAt")
+// listPRs runs gh pr list for baseFields plus extraFields. args are passed
+// on to gh pr list, e.g. a --search filter.
+func listPRs(extraFields []string, args ...string) ([]PullRequest, string, error) {
+	fields := append([]string{}, baseFields...)
+	for _, field := range extraFields {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	ghArgs := append([]string{"pr", "list", "--json", strings.Join(fields, ",")}, args...)
+	stdout, stderr, err := withRetries(func() (bytes.Buffer, bytes.Buffer, error) { return ghExec(ghArgs...) })
 	if err != nil {
 		return nil, stderr.String(), err
 	}
 
-	var prs []PullRequest
-	if err := json.Unmarshal(stdout.Bytes(), &prs); err != nil {
-		return nil, "", fmt.Errorf("failed to parse PR list: %w", err)
+	prs, err := parsePRList(stdout.Bytes())
+	if err != nil {
+		return nil, "", err
 	}
-
 	return prs, "", nil
 }
 
-func getRepoName() string {
-	stdout, _, err := gh.Exec("repo", "view", "--json", "nameWithOwner", "-q", ".nameWithOwner")
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(stdout.String())
+// defaultTimeout is how long a gh call may take without --timeout.
+const defaultTimeout = 30 * time.Second
+
+// defaultCheckoutTimeout is how long checking out may take without
+// --checkout-timeout. Fetching a big PR takes a while.
+const defaultCheckoutTimeout = 10 * time.Minute
+
+// ghTimeout is how long a gh call may take before it is stopped
+// (--timeout), and checkoutTimeout the same for checking out
+// (--checkout-timeout). 0 waits as long as gh takes.
+var (
+	ghTimeout       = defaultTimeout
+	checkoutTimeout = defaultCheckoutTimeout
+)
+
+// ghRunner runs gh for ghExec. Tests replace it with a fake.
+var ghRunner prlist.Runner = prlist.GH{}
+
+// ghExec runs gh with args and returns its output, stopping gh after
+// ghTimeout.
+func ghExec(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	return ghExecWithin(ghTimeout, args...)
 }
 
-func selectPR(prs []PullRequest) (PullRequest, bool) {
-	// Calculate column widths based on display width
-	maxIDWidth := 2
-	maxTitleWidth := 5
-	maxBranchWidth := 6
-	maxCreatedWidth := 10 // "CREATED AT"
+// ghExecWithin is ghExec stopping gh after timeout. The timeout is reported
+// on stderr too, since most callers show gh's stderr as the error.
+func ghExecWithin(timeout time.Duration, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
+	done := traceCommand("gh", args...)
+	stdout, stderr, err := ghRunner.Run(ctx, args...)
+	done(err)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("gh timed out after %s", timeout)
+		stderr.WriteString(err.Error() + "\n")
+	}
+	return stdout, stderr, err
+}
 
-	for _, pr := range prs {
-		idWidth := runewidth.StringWidth(fmt.Sprintf("#%d", pr.Number))
-		if idWidth > maxIDWidth {
-			maxIDWidth = idWidth
-		}
-		titleWidth := runewidth.StringWidth(pr.Title)
-		if titleWidth > maxTitleWidth {
-			maxTitleWidth = titleWidth
-		}
-		branchWidth := runewidth.StringWidth(pr.HeadRefName)
-		if branchWidth > maxBranchWidth {
-			maxBranchWidth = branchWidth
-		}
-		createdWidth := runewidth.StringWidth("about " + humanize.Time(pr.CreatedAt))
-		if createdWidth > maxCreatedWidth {
-			maxCreatedWidth = createdWidth
+// ghCommand is gh with args for commands that need their own stdio, e.g.
+// to show gh's output as it comes. run runs it like runCommand, stopping
+// gh after timeout.
+func ghCommand(timeout time.Duration, args ...string) (cmd *exec.Cmd, run func() error) {
+	ctx, cancel := timeoutContext(timeout)
+	cmd = exec.CommandContext(ctx, "gh", args...)
+	return cmd, func() error {
+		defer cancel()
+		err := runCommand(cmd)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("gh timed out after %s", timeout)
 		}
+		return err
 	}
+}
+
+// timeoutContext is a context that ends after timeout, or never with 0.
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
 
-	// Limit maximum widths
-	if maxTitleWidth > 100 {
-		maxTitleWidth = 100
+// parsePRList decodes the JSON array printed by gh pr list, keeping each
+// PR's raw JSON for --json.
+func parsePRList(data []byte) ([]PullRequest, error) {
+	prs, raws, err := prlist.ParseList[PullRequest](data)
+	if err != nil {
+		return nil, err
 	}
-	if maxBranchWidth > 30 {
-		maxBranchWidth = 30
+	for i := range prs {
+		prs[i].raw = raws[i]
 	}
+	return prs, nil
+}
 
-	options := make([]huh.Option[PullRequest], len(prs))
-	for i, pr := range prs {
-		label := formatPR(pr, maxIDWidth, maxTitleWidth, maxBranchWidth, maxCreatedWidth)
-		options[i] = huh.NewOption(label, pr)
+// searchQuery combines the filter flags into one gh search query, since gh
+// pr list only honors the last --search.
+func searchQuery(f flags) string {
+	var terms []string
+	if f.reviewRequested != "" {
+		terms = append(terms, "review-requested:"+f.reviewRequested)
 	}
+	if f.reviewBuckets && !slices.Contains(terms, reviewBucketSearch) {
+		terms = append(terms, reviewBucketSearch)
+	}
+	if f.notReviewedByMe {
+		terms = append(terms, "-reviewed-by:@me")
+	}
+	// --search is passed on as written; GitHub reports its mistakes
+	if f.search != "" {
+		terms = append(terms, f.search)
+	}
+	return strings.Join(terms, " ")
+}
+
+// repoOverride is the repository given with --repo, "" for the one of the
+// current directory.
+var repoOverride string
+
+// repoView runs gh repo view with args for the --repo repository, or the
+// current one. Other gh commands follow --repo through GH_REPO, but repo
+// view only takes the repository as an argument.
+func repoView(args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	if repoOverride != "" {
+		args = append([]string{repoOverride}, args...)
+	}
+	return ghExec(append([]string{"repo", "view"}, args...)...)
+}
+
+// getRepoName returns the owner/name of the current repository, or "" if it
+// cannot be determined. It is looked up once per process.
+var getRepoName = sync.OnceValue(func() string {
+	stdout, _, err := repoView("--json", "nameWithOwner", "-q", ".nameWithOwner")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(stdout.String())
+})
 
-	// Build header
-	header := buildHeader(maxIDWidth, maxTitleWidth, maxBranchWidth, maxCreatedWidth)
+func selectPR(prs []PullRequest, cols []column) (PullRequest, bool) {
+	options, header := buildOptions(prs, cols)
 
-	var selected PullRequest
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[PullRequest]().
-				Title("Select a PR to checkout:").
-				Description(header).
-				Options(options...).
-				Value(&selected),
-		),
-	)
+	// Start on the PR checked out last time if it is still listed. The value
+	// must be bound before the options for the cursor to follow it.
+	last := lastCheckout(getRepoName())
+	selected := max(0, slices.IndexFunc(prs, func(pr PullRequest) bool { return pr.Number == last }))
+	field := huh.NewSelect[int]().
+		Title(fmt.Sprintf("Select a PR to checkout (%d):", len(prs))).
+		Description(header).
+		Value(&selected).
+		Options(options...)
+	form := newForm(huh.NewGroup(field))
 
-	if err := form.Run(); err != nil {
+	filter := &selectFilter{field: field, value: &selected, options: options, cols: cols}
+	if err := runPicker(form, buildLegend(cols), field, prs, cols, filter); err != nil {
 		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
 		return PullRequest{}, false
 	}
 
-	return selected, true
+	return prs[selected], true
 }
 
-func buildHeader(idWidth, titleWidth, branchWidth, createdWidth int) string {
-	// Underline each label, no underline for padding
-	idLabel := underlineStyle.Render(runewidth.FillRight("ID", idWidth))
-	titleLabel := underlineStyle.Render(runewidth.FillRight("TITLE", titleWidth))
-	branchLabel := underlineStyle.Render(runewidth.FillRight("BRANCH", branchWidth))
-	createdLabel := underlineStyle.Render(runewidth.FillRight("CREATED AT", createdWidth))
+// selectPRs shows a multi-select picker allowing up to limit PRs to be chosen.
+func selectPRs(prs []PullRequest, cols []column, title string, limit int) ([]PullRequest, bool) {
+	options, header := buildOptions(prs, cols)
 
-	// 2 leading spaces (for cursor) + labels separated by spaces
-	return fmt.Sprintf("  %s  %s  %s  %s", idLabel, titleLabel, branchLabel, createdLabel)
-}
+	var indexes []int
+	field := huh.NewMultiSelect[int]().
+		Title(title).
+		Description(header).
+		Options(options...).
+		Limit(limit).
+		Value(&indexes)
+	form := newForm(huh.NewGroup(field))
 
-func styleID(pr PullRequest) string {
-	if pr.IsDraft {
-		return yellowStyle.Render(fmt.Sprintf("#%d", pr.Number))
+	// huh filters the multi-select itself; replacing its options would
+	// drop the toggled PRs
+	if err := runPicker(form, buildLegend(cols), field, prs, cols, nil); err != nil {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil, false
 	}
-	return greenStyle.Render(fmt.Sprintf("#%d", pr.Number))
-}
 
-func formatPR(pr PullRequest, idWidth, titleWidth, branchWidth, createdWidth int) string {
-	// ID (colored)
-	idStr := fmt.Sprintf("#%d", pr.Number)
-	paddedID := runewidth.FillRight(idStr, idWidth)
-	var styledID string
-	if pr.IsDraft {
-		styledID = yellowStyle.Render(paddedID)
-	} else {
-		styledID = greenStyle.Render(paddedID)
+	selected := make([]PullRequest, len(indexes))
+	for i, idx := range indexes {
+		selected[i] = prs[idx]
 	}
+	return selected, true
+}
 
-	// Title (truncate & pad)
-	title := pr.Title
-	if runewidth.StringWidth(title) > titleWidth {
-		title = runewidth.Truncate(title, titleWidth-1, "…")
-	}
-	paddedTitle := runewidth.FillRight(title, titleWidth)
+// checkoutPR runs gh pr checkout, into a local branch called branch if it
+// is not empty.
+func checkoutPR(pr PullRequest, branch string) error {
+	printSelected(pr)
 
-	// Branch (colored, truncate & pad)
-	branch := pr.HeadRefName
-	if runewidth.StringWidth(branch) > branchWidth {
-		branch = runewidth.Truncate(branch, branchWidth-1, "…")
+	args := checkoutArgs(pr, branch)
+	if dryRun {
+		printCommand(args...)
+		return nil
 	}
-	paddedBranch := runewidth.FillRight(branch, branchWidth)
-	styledBranch := cyanStyle.Render(paddedBranch)
-
-	// Relative time (add "about" prefix & pad)
-	created := "about " + humanize.Time(pr.CreatedAt)
-	paddedCreated := runewidth.FillRight(created, createdWidth)
-
-	return fmt.Sprintf("%s  %s  %s  %s", styledID, paddedTitle, styledBranch, grayStyle.Render(paddedCreated))
-}
-
-func checkoutPR(pr PullRequest) error {
-	// Display selected PR info
-	styledBranch := cyanStyle.Render(pr.HeadRefName)
-	fmt.Printf("%s  %s  %s\n\n", styleID(pr), pr.Title, styledBranch)
 
 	var stdoutStr, stderrStr string
 	var execErr error
 
-	_ = spinner.New().
-		Title("Checking out PR...").
-		Action(func() {
-			stdout, stderr, err := gh.Exec("pr", "checkout", strconv.Itoa(pr.Number))
-			stdoutStr = stdout.String()
-			stderrStr = stderr.String()
-			execErr = err
-		}).
-		Run()
+	runWithProgress("Checking out PR...", func() {
+		stdout, stderr, err := withRetries(func() (bytes.Buffer, bytes.Buffer, error) {
+			return ghExecWithin(checkoutTimeout, args...)
+		})
+		stdoutStr = stdout.String()
+		stderrStr = stderr.String()
+		execErr = err
+	})
 
 	if stdoutStr != "" {
 		fmt.Print(stdoutStr)
@@ -258,9 +1072,108 @@ func checkoutPR(pr PullRequest) error {
 	return nil
 }
 
+// printSelected shows the PR about to be checked out.
+func printSelected(pr PullRequest) {
+	styledBranch := cyanStyle.Render(pr.HeadRefName)
+	info := fmt.Sprintf("%s  %s  %s", styleID(pr), pr.Title, styledBranch)
+	if pr.Additions > 0 || pr.Deletions > 0 {
+		info += "  " + greenStyle.Render(fmt.Sprintf("+%d", pr.Additions)) +
+			" " + redStyle.Render(fmt.Sprintf("-%d", pr.Deletions))
+	}
+	fmt.Printf("%s\n\n", info)
+}
+
 type flags struct {
-	web  bool
-	view bool
+	web             bool
+	view            bool
+	openIssue       bool
+	conflictCheck   bool
+	locale          string
+	sort            string
+	columns         []string
+	noTruncate      bool
+	legend          bool
+	zebra           bool
+	noHelp          bool
+	borders         bool
+	limit           int
+	minFiles        int
+	compare         bool
+	progress        string
+	timeout         time.Duration
+	checkoutTimeout time.Duration
+	retries         int
+	noColor         bool
+	noMouse         bool
+	debug           bool
+	theme           string
+	plain           bool
+	repo            string
+	hostname        string
+	interactive     bool
+	preview         bool
+	dryRun          bool
+	worktree        string
+	force           bool
+	recreate        bool
+	showVersion     bool
+	maxFiles        int
+	author          string
+	assignee        string
+	milestone       string
+	multi           bool
+	approve         bool
+	body            string
+	json            bool
+	print           bool
+	jsonPretty      bool
+	jsonCompact     bool
+	tuiView         bool
+	query           string
+	protocol        string
+	reviewBuckets   bool
+	branchTemplate  *template.Template
+	reviewRequested string
+	notReviewedByMe bool
+	hideOrphanBase  bool
+	jsonHelp        bool
+	project         int
+	projectStatus   string
+	yes             bool
+	sla             time.Duration
+	staleAfter      time.Duration
+	olderThan       time.Duration
+	olderThanText   string
+	merge           bool
+	mergeMethod     string
+	deleteBranch    bool
+	alwaysPrompt    bool
+	sincePR         int
+	excludeBase     []string
+	labels          []string
+	search          string
+	copy            string
+	base            string
+	difftool        bool
+	checks          bool
+	oldestFirst     bool
+	reverse         bool
+	updated         bool
+	wide            bool
+	showCoauthors   bool
+	truncateOrder   []string
+	liveMergeable   bool
+	behindAtMost    int
+	session         bool
+	ensurePR        bool
+	summary         bool
+	state           string
+	reopen          bool
+	stash           bool
+	stashPop        bool
+	maxSize         string
+	// set holds the names of the flags given on the command line
+	set map[string]bool
 }
 
 func parseFlags() flags {
@@ -269,26 +1182,485 @@ func parseFlags() flags {
 Optionally open the PR in the browser.
 
 USAGE
-  gh po [flags]
+  gh po [flags] [<number> | <branch> | <title query>]
 
 FLAGS
-  -w, --web     Open the PR in browser after checkout
-  -v, --view    Open the PR in browser without checkout
-  --help        Show help for command
+  -R, --repo OWNER/NAME
+                      Use another repository than the current directory's
+  --hostname HOST     Use the GitHub Enterprise host HOST (default: the host of
+                      the current repository or gh's default host)
+  -w, --web           Open the PR in browser after checkout
+  -v, --view          Open the PR in browser without checkout
+  -i, --interactive   Choose what to do with the selected PR: checkout, open
+                      in browser, view diff, comment, approve or close
+  --compare           Open the comparison of the PR's base and head branches
+                      in browser without checkout (with --view, both)
+  -t, --worktree PATH
+                      Check out into a new git worktree at PATH instead of the
+                      current working tree
+  --stash             Stash uncommitted changes before checkout
+  --stash-pop         Restore the changes --stash put away on the current
+                      branch, then exit
+  --protocol PROTO    Make the git remote use ssh or https before checkout
+  --branch-template TEMPLATE
+                      Name the local branch from a Go template with {{.Author}},
+                      {{.Branch}}, {{.Number}}, {{.Title}} and {{.Base}}
+  -f, --force         Reset the local branch to the PR's head even if it
+                      diverged, e.g. after a force-push
+  --recreate          Delete the PR's local branch before checking it out
+                      again, after a confirmation
+  -y, --yes           Check out without asking when there are uncommitted
+                      changes, and even if the head branch is protected; delete
+                      the branch of --recreate without asking
+  --dry-run           Print the gh commands that would check out or open the
+                      PR instead of running them
+  --tui-view          Read the PR's description, comments and diff in the
+                      terminal without checkout
+  --difftool          Open the PR's changes in git difftool without checkout
+  --checks            List the PR's CI checks without checkout, exiting non-zero
+                      unless they all passed
+  --json              Print the selected PR as JSON instead of checking out
+  --print             Print the selected PR's head branch instead of checking
+                      out, e.g. git log $(gh po --print)
+  --copy WHAT         Copy the selected PR's branch or url to the clipboard
+                      instead of checking out
+  --json-pretty       Indent the JSON output (default when stdout is a terminal)
+  --json-compact      Print the JSON output on one line (default when piped)
+  --json-help         List the fields --json can print and their types
+  --session           Walk through the PRs awaiting my review one by one to
+                      read, open, approve or skip each; resumes where I left off
+  --ensure-pr         Offer to create a PR for the current branch if it has none
+  --summary           Print PR counts by state, draft and review decision and
+                      the oldest open PR, then exit (JSON with --json)
+  --approve           Approve the selected PR instead of checking out
+  --multi             Select several PRs at once (with --approve)
+  --body TEXT         Comment to add when approving (also in --session and
+                      --interactive)
+  --merge             Merge the selected PR instead of checking out
+  --merge-commit, --squash, --rebase
+                      Merge method (with --merge; asked for if not given)
+  --delete-branch     Delete the head branch after merging (with --merge)
+  --reopen            Reopen the selected PR (with --state closed)
+  --open-issue        Also open the issues the PR closes in browser
+  -L, --limit N       Fetch at most N PRs (default 30)
+  --state STATE       List PRs in STATE: open, closed, merged, all (default open)
+  --review-buckets    Only PRs awaiting my review, grouped into today, this
+                      week and older by last update, oldest first
+  --review-requested USER
+                      Only PRs whose review is requested from USER (@me for you)
+  --not-reviewed-by-me
+                      Only PRs I have not reviewed yet
+  --author USER       Only PRs opened by USER (@me for you)
+  --assignee USER     Only PRs assigned to USER (@me for you)
+  --milestone NAME    Only PRs in the milestone NAME or number
+  --label NAME        Only PRs labeled NAME (repeatable; PRs need every label)
+  --search QUERY      Only PRs matching the GitHub search QUERY, e.g.
+                      "review:required draft:false"
+  --project NUMBER    Only PRs on the repository owner's project board NUMBER
+  --project-status NAME
+                      Only PRs in the Status column NAME (with --project)
+  --older-than AGE    Only PRs opened more than AGE ago (e.g. 7d, 2w, 36h)
+  --since-pr N        Only PRs numbered after #N
+  --base BRANCH       Only PRs targeting BRANCH
+  --exclude-base BRANCH
+                      Hide PRs targeting BRANCH (repeatable)
+  --max-size SIZE     Only PRs of SIZE or smaller: XS, S, M, L, XL
+  --min-files N       Only PRs changing at least N files
+  --max-files N       Only PRs changing at most N files
+  --behind-at-most N  Only PRs whose head is at most N commits behind the base
+  --hide-orphan-base  Hide PRs whose base branch no longer exists
+  --sort FIELD        Sort PRs by FIELD: created, updated, number, title,
+                      urgency, waiting (default newest first; press s in the
+                      picker to change it)
+  --reverse           Reverse the sort order
+  --oldest-first      Sort PRs by number, oldest first (same as --sort number)
+  --columns LIST      Extra columns to show, comma-separated: assignees, author,
+                      base, behind, checks, ci-time, flow, labels, review,
+                      size, urgency, waiting
+  --updated           Show when PRs were last updated instead of created
+  --wide              Also show the author, the base branch, the head commit
+                      and when PRs were last updated, and the owner of forks
+  --show-coauthors    Count commit co-authors in the AUTHOR column (adds it)
+  --no-truncate       Show full titles and branches even if rows overflow
+  --truncate-order LIST
+                      Columns to shrink first when rows are wider than the
+                      terminal, comma-separated (default title,branch)
+  --sla AGE           Show the age of PRs open longer than AGE in red, and of
+                      those past three quarters of it in yellow
+  --stale-after AGE   Show when PRs without activity for AGE were created or
+                      updated in yellow (default 30d; 0 turns it off)
+  --zebra             Shade every other row for readability
+  --borders           Draw borders around the table cells
+  --preview           Show the description of the highlighted PR below the
+                      picker (press p in the picker to toggle it)
+  --no-help           Hide the key hints below the picker
+  --no-mouse          Don't select with clicks and scroll with the mouse wheel
+                      in the picker, e.g. in a multiplexer mixing them up
+  --no-color          Print plain text without colors (also with NO_COLOR set)
+  --theme NAME        Colors for the table and the forms: default, dracula,
+                      base16 or mono
+  --plain             List the PRs as plain text instead of opening the picker
+                      (the default when stdout is not a terminal)
+  --progress MODE     Show progress as a spinner, dots or none (default
+                      spinner, dots if TERM is dumb)
+  --timeout DURATION  Stop a gh call that takes longer than DURATION, e.g. 1m
+                      (default 30s; 0 waits forever)
+  --checkout-timeout DURATION
+                      Stop checking out after DURATION (default 10m; 0 waits
+                      forever)
+  --retries N         Retry listing and checking out up to N times when gh
+                      fails for a passing reason, e.g. HTTP 502 (default 2)
+  --debug             Log the parsed flags and every gh and git command with
+                      how long it took to stderr
+  --legend            Explain the symbols and colors of the enabled columns
+                      (press ? in the picker to toggle it)
+  --locale LANG       Language for relative times (en, de, es, fr, ja; default en)
+  --conflict-check    Report which of the selected PRs conflict with each other
+                      (experimental, slow: trial-merges every pair locally)
+  --always-prompt     Show the picker even when a title query has a clear match
+  --live-mergeable    Trial-merge up to 5 selected PRs into the current tip of
+                      their base and report clean or conflicting (experimental)
+  -V, --version       Show the version, commit and build date
+  --help              Show help for command
+
+ENVIRONMENT
+  GH_PO_AUDIT_LOG     Append a JSON line per successful action to this file
+  GH_PO_COLOR_NAME    Replace one color of the theme with an ANSI number or a
+                      hex color, e.g. GH_PO_COLOR_DRAFT=#8250df; NAME is RED,
+                      GREEN, YELLOW, MAGENTA, CYAN, GRAY, TEXT, DRAFT or READY
+  GH_PO_DEFAULT_FLAGS Flags applied before the command line ones, which win,
+                      e.g. "--zebra --sort urgency"
+  NO_COLOR            Set to anything to print plain text, like --no-color
+
+ARGUMENTS
+  A PR number (1234 or #1234) or head branch selects that PR directly.
+  Anything else is a title query fuzzy-matched against PR titles. A single
+  clear match is checked out directly; otherwise the picker opens with only
+  the matches. --always-prompt takes precedence and opens the picker for a
+  clear match too.
 
 EXAMPLES
-  $ gh po              # Checkout only
-  $ gh po --web        # Checkout and open in browser
-  $ gh po --view       # Open in browser without checkout
+  $ gh po                     # Checkout only
+  $ gh po 1234 --web          # Checkout PR #1234 and open it in browser
+  $ gh po feature/login       # Checkout the PR of a branch
+  $ gh po login bug           # Checkout the PR whose title best matches
+  $ gh po --web               # Checkout and open in browser
+  $ gh po --view              # Open in browser without checkout
+  $ gh po --open-issue        # Checkout and open the linked issues
+  $ gh po --branch-template 'review/{{.Author}}/{{.Branch}}'
+  $ gh po --json | jq -r .headRefName
+  $ git log $(gh po --print)
+  $ gh po --plain | grep login
+  $ gh po --merge --squash --delete-branch
+  $ gh po --multi --approve   # Approve several PRs after one confirmation
+  $ gh po --review-buckets    # Work through my review queue oldest first
+  $ gh po --review-requested @me --not-reviewed-by-me
+  $ gh po --project 3 --project-status "In review"
+  $ gh po --older-than 7d --sla 14d
+  $ gh po --sort urgency      # Most urgent PRs for me first
+  $ gh po --columns flow      # Show fork:branch → owner:base for each PR
+  $ gh po --locale ja         # Show relative times in Japanese
+  $ gh po --conflict-check    # Find conflicting pairs among selected PRs
 `)
 	}
 
 	var f flags
+	var columns, branchTemplate, sla, staleAfter, truncateOrder string
+	var mergeCommit, squash, rebase bool
 	flag.BoolVar(&f.web, "web", false, "")
 	flag.BoolVar(&f.web, "w", false, "")
 	flag.BoolVar(&f.view, "view", false, "")
 	flag.BoolVar(&f.view, "v", false, "")
-	flag.Parse()
+	flag.BoolVar(&f.compare, "compare", false, "")
+	flag.StringVar(&f.protocol, "protocol", "", "")
+	flag.StringVar(&branchTemplate, "branch-template", "", "")
+	flag.BoolVar(&f.yes, "yes", false, "")
+	flag.BoolVar(&f.yes, "y", false, "")
+	flag.BoolVar(&f.tuiView, "tui-view", false, "")
+	flag.BoolVar(&f.difftool, "difftool", false, "")
+	flag.BoolVar(&f.checks, "checks", false, "")
+	flag.BoolVar(&f.json, "json", false, "")
+	flag.BoolVar(&f.print, "print", false, "")
+	flag.BoolVar(&f.jsonPretty, "json-pretty", false, "")
+	flag.BoolVar(&f.jsonCompact, "json-compact", false, "")
+	flag.BoolVar(&f.jsonHelp, "json-help", false, "")
+	flag.BoolVar(&f.session, "session", false, "")
+	flag.BoolVar(&f.ensurePR, "ensure-pr", false, "")
+	flag.BoolVar(&f.summary, "summary", false, "")
+	flag.StringVar(&f.state, "state", "open", "")
+	flag.BoolVar(&f.reopen, "reopen", false, "")
+	flag.BoolVar(&f.stash, "stash", false, "")
+	flag.BoolVar(&f.stashPop, "stash-pop", false, "")
+	flag.StringVar(&f.maxSize, "max-size", "", "")
+	flag.IntVar(&f.minFiles, "min-files", -1, "")
+	flag.IntVar(&f.maxFiles, "max-files", -1, "")
+	flag.BoolVar(&f.approve, "approve", false, "")
+	flag.BoolVar(&f.multi, "multi", false, "")
+	flag.StringVar(&f.body, "body", "", "")
+	flag.BoolVar(&f.merge, "merge", false, "")
+	flag.BoolVar(&mergeCommit, "merge-commit", false, "")
+	flag.BoolVar(&squash, "squash", false, "")
+	flag.BoolVar(&rebase, "rebase", false, "")
+	flag.BoolVar(&f.deleteBranch, "delete-branch", false, "")
+	flag.BoolVar(&f.openIssue, "open-issue", false, "")
+	flag.BoolVar(&f.conflictCheck, "conflict-check", false, "")
+	flag.BoolVar(&f.liveMergeable, "live-mergeable", false, "")
+	flag.StringVar(&f.locale, "locale", "en", "")
+	flag.BoolVar(&f.reviewBuckets, "review-buckets", false, "")
+	flag.StringVar(&f.reviewRequested, "review-requested", "", "")
+	flag.StringVar(&f.author, "author", "", "")
+	flag.StringVar(&f.assignee, "assignee", "", "")
+	flag.StringVar(&f.milestone, "milestone", "", "")
+	flag.StringVar(&f.search, "search", "", "")
+	flag.BoolVar(&f.notReviewedByMe, "not-reviewed-by-me", false, "")
+	flag.StringVar(&f.olderThanText, "older-than", "", "")
+	flag.IntVar(&f.sincePR, "since-pr", 0, "")
+	flag.StringVar(&f.base, "base", "", "")
+	flag.Func("exclude-base", "", func(s string) error {
+		f.excludeBase = append(f.excludeBase, s)
+		return nil
+	})
+	flag.Func("label", "", func(s string) error {
+		f.labels = append(f.labels, s)
+		return nil
+	})
+	flag.IntVar(&f.behindAtMost, "behind-at-most", -1, "")
+	flag.BoolVar(&f.hideOrphanBase, "hide-orphan-base", false, "")
+	flag.IntVar(&f.project, "project", 0, "")
+	flag.StringVar(&f.projectStatus, "project-status", "", "")
+	flag.StringVar(&f.sort, "sort", "", "")
+	flag.BoolVar(&f.oldestFirst, "oldest-first", false, "")
+	flag.BoolVar(&f.reverse, "reverse", false, "")
+	flag.BoolVar(&f.updated, "updated", false, "")
+	flag.BoolVar(&f.wide, "wide", false, "")
+	flag.StringVar(&columns, "columns", "", "")
+	flag.BoolVar(&f.showCoauthors, "show-coauthors", false, "")
+	flag.BoolVar(&f.noTruncate, "no-truncate", false, "")
+	flag.StringVar(&truncateOrder, "truncate-order", "", "")
+	flag.BoolVar(&f.legend, "legend", false, "")
+	flag.BoolVar(&f.alwaysPrompt, "always-prompt", false, "")
+	flag.StringVar(&sla, "sla", "", "")
+	flag.StringVar(&staleAfter, "stale-after", "", "")
+	flag.BoolVar(&f.zebra, "zebra", false, "")
+	flag.BoolVar(&f.noHelp, "no-help", false, "")
+	flag.BoolVar(&f.noColor, "no-color", false, "")
+	flag.BoolVar(&f.noMouse, "no-mouse", false, "")
+	flag.StringVar(&f.theme, "theme", defaultTheme, "")
+	flag.BoolVar(&f.plain, "plain", false, "")
+	flag.StringVar(&f.repo, "repo", "", "")
+	flag.StringVar(&f.repo, "R", "", "")
+	flag.StringVar(&f.hostname, "hostname", "", "")
+	flag.BoolVar(&f.interactive, "interactive", false, "")
+	flag.BoolVar(&f.interactive, "i", false, "")
+	flag.BoolVar(&f.borders, "borders", false, "")
+	flag.BoolVar(&f.preview, "preview", false, "")
+	flag.BoolVar(&f.dryRun, "dry-run", false, "")
+	flag.StringVar(&f.worktree, "worktree", "", "")
+	flag.BoolVar(&f.force, "force", false, "")
+	flag.BoolVar(&f.force, "f", false, "")
+	flag.BoolVar(&f.recreate, "recreate", false, "")
+	flag.StringVar(&f.worktree, "t", "", "")
+	flag.StringVar(&f.copy, "copy", "", "")
+	flag.BoolVar(&f.showVersion, "version", false, "")
+	flag.BoolVar(&f.showVersion, "V", false, "")
+	flag.StringVar(&f.progress, "progress", defaultProgressMode(), "")
+	flag.DurationVar(&f.timeout, "timeout", defaultTimeout, "")
+	flag.DurationVar(&f.checkoutTimeout, "checkout-timeout", defaultCheckoutTimeout, "")
+	flag.IntVar(&f.retries, "retries", defaultRetries, "")
+	flag.BoolVar(&f.debug, "debug", false, "")
+	flag.IntVar(&f.limit, "limit", defaultLimit, "")
+	flag.IntVar(&f.limit, "L", defaultLimit, "")
+	// Hidden subcommands for shell completion, which need the flags above
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "completion":
+			if err := printCompletionScript(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			os.Exit(0)
+		case completeCommand:
+			complete(os.Args[2:])
+			os.Exit(0)
+		}
+	}
+	if err := parseDefaultFlags(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid GH_PO_DEFAULT_FLAGS: %v\n", err)
+		os.Exit(2)
+	}
+	// Flags may follow the positional arguments, e.g. "gh po 1234 --web".
+	// The flag package stops at the first non-flag, so resume after it.
+	args := os.Args[1:]
+	var positional []string
+	for {
+		_ = flag.CommandLine.Parse(args)
+		rest := flag.Args()
+		if len(rest) == 0 {
+			break
+		}
+		// Everything after "--" is positional
+		if len(args) > len(rest) && args[len(args)-len(rest)-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	f.set = map[string]bool{}
+	flag.Visit(func(fl *flag.Flag) { f.set[fl.Name] = true })
+	f.query = strings.Join(positional, " ")
+
+	if f.oldestFirst {
+		if f.sort != "" && f.sort != "number" {
+			fmt.Fprintln(os.Stderr, "`--oldest-first` is `--sort number` and cannot be combined with another `--sort`")
+			os.Exit(2)
+		}
+		f.sort = "number"
+	}
+	if f.sort != "" && !validSortOrder(f.sort) {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--sort\" flag: valid values are %s\n", f.sort, strings.Join(sortOrders, ", "))
+		os.Exit(2)
+	}
+	if f.protocol != "" && f.protocol != "ssh" && f.protocol != "https" {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--protocol\" flag: valid values are ssh, https\n", f.protocol)
+		os.Exit(2)
+	}
+	if branchTemplate != "" {
+		tmpl, err := parseBranchTemplate(branchTemplate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--branch-template\" flag: %v\n", branchTemplate, err)
+			os.Exit(2)
+		}
+		f.branchTemplate = tmpl
+	}
+	if sla != "" {
+		d, err := parseAge(sla)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--sla\" flag: %s\n", sla, ageHint)
+			os.Exit(2)
+		}
+		f.sla = d
+	}
+	f.staleAfter = defaultStaleAfter
+	if staleAfter == "0" {
+		f.staleAfter = 0
+	} else if staleAfter != "" {
+		d, err := parseAge(staleAfter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--stale-after\" flag: %s, or 0 to turn it off\n", staleAfter, ageHint)
+			os.Exit(2)
+		}
+		f.staleAfter = d
+	}
+	if f.olderThanText != "" {
+		d, err := parseAge(f.olderThanText)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--older-than\" flag: %s\n", f.olderThanText, ageHint)
+			os.Exit(2)
+		}
+		f.olderThan = d
+	}
+	methods := 0
+	for method, set := range map[string]bool{"merge": mergeCommit, "squash": squash, "rebase": rebase} {
+		if set {
+			f.mergeMethod = method
+			methods++
+		}
+	}
+	if methods > 1 {
+		fmt.Fprintln(os.Stderr, "specify only one of `--merge-commit`, `--squash` or `--rebase`")
+		os.Exit(2)
+	}
+	// A session is about the PRs awaiting my review
+	if f.session && f.reviewRequested == "" {
+		f.reviewRequested = "@me"
+	}
+	if f.set["behind-at-most"] && f.behindAtMost < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--behind-at-most\" flag: must be zero or more\n", f.behindAtMost)
+		os.Exit(2)
+	}
+	if f.maxSize != "" {
+		if !slices.Contains(sizes, strings.ToUpper(f.maxSize)) {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--max-size\" flag: valid values are %s\n", f.maxSize, strings.Join(sizes, ", "))
+			os.Exit(2)
+		}
+		f.maxSize = strings.ToUpper(f.maxSize)
+	}
+	if f.set["min-files"] && f.minFiles < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--min-files\" flag: must be zero or more\n", f.minFiles)
+		os.Exit(2)
+	}
+	if f.set["max-files"] && f.maxFiles < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--max-files\" flag: must be zero or more\n", f.maxFiles)
+		os.Exit(2)
+	}
+	if _, ok := themes[f.theme]; !ok {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--theme\" flag: valid values are %s\n", f.theme, strings.Join(sortedKeys(themes), ", "))
+		os.Exit(2)
+	}
+	if !slices.Contains(progressModes, f.progress) {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--progress\" flag: valid values are %s\n", f.progress, strings.Join(progressModes, ", "))
+		os.Exit(2)
+	}
+	if f.limit <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--limit\" flag: must be a positive number\n", f.limit)
+		os.Exit(2)
+	}
+	if f.repo != "" && strings.Count(f.repo, "/") != 1 && strings.Count(f.repo, "/") != 2 {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--repo\" flag: expected the OWNER/NAME or HOST/OWNER/NAME format\n", f.repo)
+		os.Exit(2)
+	}
+	if f.copy != "" && !slices.Contains(copyTargets, f.copy) {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--copy\" flag: valid values are %s\n", f.copy, strings.Join(copyTargets, ", "))
+		os.Exit(2)
+	}
+	if !validState(f.state) {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--state\" flag: valid values are %s\n", f.state, strings.Join(prStates, ", "))
+		os.Exit(2)
+	}
+	if slices.Contains(f.labels, "") {
+		fmt.Fprintln(os.Stderr, "invalid argument \"\" for \"--label\" flag: must be a label name")
+		os.Exit(2)
+	}
+	if slices.Contains(f.excludeBase, "") {
+		fmt.Fprintln(os.Stderr, "invalid argument \"\" for \"--exclude-base\" flag: must be a branch name")
+		os.Exit(2)
+	}
+	if f.set["since-pr"] && f.sincePR <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--since-pr\" flag: must be a positive PR number\n", f.sincePR)
+		os.Exit(2)
+	}
+	if f.retries < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument \"%d\" for \"--retries\" flag: must not be negative\n", f.retries)
+		os.Exit(2)
+	}
+	if f.timeout < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--timeout\" flag: must not be negative\n", f.timeout)
+		os.Exit(2)
+	}
+	if f.checkoutTimeout < 0 {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--checkout-timeout\" flag: must not be negative\n", f.checkoutTimeout)
+		os.Exit(2)
+	}
+	if err := validateCombinations(f); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	f.columns = splitList(columns)
+	if f.set["truncate-order"] {
+		f.truncateOrder = splitList(truncateOrder)
+	}
+	for _, name := range f.columns {
+		if _, ok := optionalColumns[name]; !ok {
+			fmt.Fprintf(os.Stderr, "invalid argument %q for \"--columns\" flag: valid columns are %s\n", name, strings.Join(optionalColumnNames(), ", "))
+			os.Exit(2)
+		}
+	}
+
+	if !setLocale(f.locale) {
+		fmt.Fprintf(os.Stderr, "invalid argument %q for \"--locale\" flag: supported locales are %s\n", f.locale, strings.Join(supportedLocales(), ", "))
+		os.Exit(2)
+	}
 	return f
 }
 
@@ -296,11 +1668,66 @@ func browsePR(pr PullRequest, withNewline bool) error {
 	if withNewline {
 		fmt.Println()
 	}
-	cmd := exec.Command("gh", "browse", strconv.Itoa(pr.Number))
+	if dryRun {
+		printCommand(browseArgs(pr)...)
+		return nil
+	}
+	cmd, run := browseCommand(pr)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	if err := run(); err != nil {
 		return fmt.Errorf("failed to open PR #%d in browser: %w", pr.Number, err)
 	}
 	return nil
 }
+
+// browseCommand is gh browse for pr, stopped after ghTimeout. Without stdio
+// attached it runs alongside a TUI without touching the terminal.
+func browseCommand(pr PullRequest) (*exec.Cmd, func() error) {
+	return ghCommand(ghTimeout, browseArgs(pr)...)
+}
+
+// browseArgs are the gh arguments that open pr in the browser.
+func browseArgs(pr PullRequest) []string {
+	return []string{"browse", strconv.Itoa(pr.Number)}
+}
+
+func runGit(args ...string) (string, string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := runCommand(cmd)
+	return stdout.String(), stderr.String(), err
+}
+
+// terminalWidth returns the width of the terminal the picker is shown on,
+// or 0 if it is not a terminal.
+func terminalWidth() int {
+	for _, file := range []*os.File{os.Stdout, os.Stderr} {
+		if w, _, err := term.GetSize(file.Fd()); err == nil && w > 0 {
+			return w
+		}
+	}
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
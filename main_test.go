@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRunner is a gh that prints stdout and stderr and fails with err,
+// recording the arguments it was called with.
+type fakeRunner struct {
+	stdout, stderr string
+	err            error
+	args           *[]string
+}
+
+func (r fakeRunner) Run(_ context.Context, args ...string) (bytes.Buffer, bytes.Buffer, error) {
+	*r.args = args
+	return *bytes.NewBufferString(r.stdout), *bytes.NewBufferString(r.stderr), r.err
+}
+
+// fakeGh makes ghExec run a fakeRunner, returning the arguments it will
+// record.
+func fakeGh(t *testing.T, stdout, stderr string, err error) *[]string {
+	t.Helper()
+	args := new([]string)
+	orig := ghRunner
+	ghRunner = fakeRunner{stdout: stdout, stderr: stderr, err: err, args: args}
+	t.Cleanup(func() { ghRunner = orig })
+	return args
+}
+
+func TestListPRs(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdout  string
+		want    []int
+		wantErr bool
+	}{
+		{name: "zero PRs", stdout: "[]", want: []int{}},
+		{name: "one PR", stdout: `[{"number":1,"title":"Fix bug"}]`, want: []int{1}},
+		{name: "many PRs", stdout: `[{"number":3},{"number":2},{"number":1}]`, want: []int{3, 2, 1}},
+		{name: "invalid JSON", stdout: `[{"number":`, wantErr: true},
+		{name: "wrong types", stdout: `[{"number":"one"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGh(t, tt.stdout, "", nil)
+			prs, _, err := listPRs(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("listPRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := make([]int, len(prs))
+			for i, pr := range prs {
+				got[i] = pr.Number
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("listPRs() numbers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPRsArgs(t *testing.T) {
+	args := fakeGh(t, "[]", "", nil)
+	if _, _, err := listPRs([]string{"author", "title"}, "--state=closed"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"pr", "list", "--json", strings.Join(slices.Concat(baseFields, []string{"author"}), ","), "--state=closed"}
+	if !slices.Equal(*args, want) {
+		t.Errorf("gh called with %q, want %q", *args, want)
+	}
+}
+
+func TestListPRsGhError(t *testing.T) {
+	fakeGh(t, "", "no git remotes found\n", errors.New("exit status 1"))
+	prs, stderr, err := listPRs(nil)
+	if err == nil || prs != nil {
+		t.Fatalf("listPRs() = %v, %v, want an error", prs, err)
+	}
+	if stderr != "no git remotes found\n" {
+		t.Errorf("listPRs() stderr = %q", stderr)
+	}
+}
+
+func TestParsePRListZeroCreatedAt(t *testing.T) {
+	for _, data := range []string{`[{"number":1}]`, `[{"number":1,"createdAt":null}]`} {
+		prs, err := parsePRList([]byte(data))
+		if err != nil {
+			t.Fatalf("parsePRList(%s) error = %v", data, err)
+		}
+		if !prs[0].CreatedAt.IsZero() {
+			t.Errorf("parsePRList(%s) CreatedAt = %v, want zero", data, prs[0].CreatedAt)
+		}
+		if got := createdColumn.value(prs[0]); got != "-" {
+			t.Errorf("CREATED AT of %s = %q, want %q", data, got, "-")
+		}
+	}
+}
+
+func TestJSONExportKeepsFullTitle(t *testing.T) {
+	title := strings.Repeat("A very long title ", 20) + "ログイン画面を修正"
+	prs, err := parsePRList([]byte(`[{"number":1,"title":"` + title + `"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pretty := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, prs[0].raw, pretty); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), title) {
+			t.Errorf("writeJSON(pretty=%v) = %s, want the full title", pretty, buf.String())
+		}
+	}
+}
+
+func TestGhCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fakes gh with a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "gh"), []byte("#!/bin/sh\nexec sleep 5\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	_, run := ghCommand(50*time.Millisecond, "browse", "12")
+	want := "gh timed out after 50ms"
+	if err := run(); err == nil || err.Error() != want {
+		t.Errorf("ghCommand() error = %v, want %q", err, want)
+	}
+}
+
+func TestGhExecTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fakes gh with a shell script")
+	}
+	script := filepath.Join(t.TempDir(), "gh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nexec sleep 5\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GH_PATH", script)
+	orig := ghTimeout
+	ghTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { ghTimeout = orig })
+
+	_, stderr, err := ghExec("pr", "list")
+	want := "gh timed out after 50ms"
+	if err == nil || err.Error() != want {
+		t.Errorf("ghExec() error = %v, want %q", err, want)
+	}
+	if stderr.String() != want+"\n" {
+		t.Errorf("ghExec() stderr = %q, want %q", stderr.String(), want+"\n")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/huh"
+)
+
+// mergeMethods are the gh pr merge methods, in the order they are offered.
+var mergeMethods = []string{"merge", "squash", "rebase"}
+
+var mergeMethodTitles = map[string]string{
+	"merge":  "Create a merge commit",
+	"squash": "Squash and merge",
+	"rebase": "Rebase and merge",
+}
+
+// mergePR merges pr with method after a confirmation, asking for the method
+// first if it is empty. gh's output and errors are shown as they are.
+func mergePR(pr PullRequest, method string, deleteBranch bool) error {
+	if method == "" {
+		options := make([]huh.Option[string], len(mergeMethods))
+		for i, m := range mergeMethods {
+			options[i] = huh.NewOption(mergeMethodTitles[m], m)
+		}
+		err := newForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(fmt.Sprintf("How should PR #%d be merged?", pr.Number)).
+					Options(options...).
+					Value(&method),
+			),
+		).Run()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+			return nil
+		}
+	}
+
+	description := mergeMethodTitles[method]
+	if deleteBranch {
+		description += ", then delete " + cyanStyle.Render(pr.HeadRefName)
+	} else {
+		description += ", keeping " + cyanStyle.Render(pr.HeadRefName)
+	}
+	confirmed := false
+	err := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Merge PR #%d %s?", pr.Number, pr.Title)).
+				Description(description).
+				Value(&confirmed),
+		),
+	).Run()
+	if err != nil || !confirmed {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	var stdoutStr, stderrStr string
+	var execErr error
+
+	runWithProgress(fmt.Sprintf("Merging PR #%d...", pr.Number), func() {
+		args := []string{"pr", "merge", strconv.Itoa(pr.Number), "--" + method}
+		if deleteBranch {
+			args = append(args, "--delete-branch")
+		}
+		stdout, stderr, err := ghExec(args...)
+		stdoutStr = stdout.String()
+		stderrStr = stderr.String()
+		execErr = err
+	})
+
+	if stdoutStr != "" {
+		fmt.Print(stdoutStr)
+	}
+	if stderrStr != "" {
+		fmt.Print(stderrStr)
+	}
+	if execErr != nil {
+		return fmt.Errorf("failed to merge PR #%d: %w", pr.Number, execErr)
+	}
+	audit.record(pr, "merge")
+	return nil
+}
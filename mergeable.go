@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxLiveMergeablePRs bounds the selection for --live-mergeable, since every
+// PR is fetched and trial-merged locally.
+const maxLiveMergeablePRs = 5
+
+// mergeableRefPrefix is where PR heads and bases are fetched to during
+// --live-mergeable. The refs are deleted again once the check completes.
+const mergeableRefPrefix = "refs/gh-po/mergeable/"
+
+// liveMergeableFields are the gh pr list fields --live-mergeable needs.
+var liveMergeableFields = []string{"baseRefName"}
+
+func runLiveMergeable(prs []PullRequest, cols []column) error {
+	title := fmt.Sprintf("Select PRs to trial-merge into their base (experimental, up to %d):", maxLiveMergeablePRs)
+	selected, ok := selectPRs(prs, cols, title, maxLiveMergeablePRs)
+	if !ok {
+		return nil
+	}
+	if len(selected) == 0 {
+		return errors.New("select at least one PR to check")
+	}
+
+	var conflicts map[int]bool
+	var checkErr error
+
+	runWithProgress(fmt.Sprintf("Trial-merging %d PR(s) into their base (experimental)...", len(selected)), func() {
+		conflicts, checkErr = checkMergeable(selected)
+	})
+
+	if checkErr != nil {
+		return checkErr
+	}
+
+	for _, pr := range selected {
+		if conflicts[pr.Number] {
+			fmt.Printf("%s %s  %s  %s\n", redStyle.Render("✗"), styleID(pr), pr.Title, redStyle.Render("conflicts with "+pr.BaseRefName))
+		} else {
+			fmt.Printf("%s %s  %s  %s\n", greenStyle.Render("✓"), styleID(pr), pr.Title, grayStyle.Render("merges cleanly into "+pr.BaseRefName))
+		}
+	}
+	return nil
+}
+
+// checkMergeable fetches the head of every PR and the current tip of its
+// base, then trial-merges each head into its base in a temporary worktree.
+// The returned map is keyed by PR number and is true for conflicts.
+func checkMergeable(prs []PullRequest) (map[int]bool, error) {
+	stdout, stderr, err := repoView("--json", "url", "-q", ".url")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository URL: %s", strings.TrimSpace(stderr.String()))
+	}
+	url := strings.TrimSpace(stdout.String())
+
+	headRef := func(pr PullRequest) string { return mergeableRefPrefix + "pull/" + strconv.Itoa(pr.Number) }
+	baseRef := func(pr PullRequest) string { return mergeableRefPrefix + "base/" + pr.BaseRefName }
+
+	refs := map[string]bool{}
+	fetchArgs := []string{"fetch", "--quiet", "--no-tags", url}
+	for _, pr := range prs {
+		fetchArgs = append(fetchArgs, fmt.Sprintf("+refs/pull/%d/head:%s", pr.Number, headRef(pr)))
+		refs[headRef(pr)] = true
+		if !refs[baseRef(pr)] {
+			fetchArgs = append(fetchArgs, "+refs/heads/"+pr.BaseRefName+":"+baseRef(pr))
+			refs[baseRef(pr)] = true
+		}
+	}
+	defer func() {
+		for ref := range refs {
+			_, _, _ = runGit("update-ref", "-d", ref)
+		}
+	}()
+	if _, stderr, err := runGit(fetchArgs...); err != nil {
+		return nil, fmt.Errorf("failed to fetch PR heads and bases: %s", strings.TrimSpace(stderr))
+	}
+
+	conflicts := make(map[int]bool)
+	err = withTempWorktree("gh-po-mergeable-", func(dir string) error {
+		for _, pr := range prs {
+			conflict, err := tryMerge(dir, baseRef(pr), headRef(pr))
+			if err != nil {
+				return fmt.Errorf("failed to merge PR #%d into %s: %w", pr.Number, pr.BaseRefName, err)
+			}
+			conflicts[pr.Number] = conflict
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseEnabled lets the picker take clicks and the mouse wheel (disabled
+// by --no-mouse and on terminals that can't report them).
+var mouseEnabled bool
+
+// mouseSupported reports whether the terminal is expected to report mouse
+// events: the Linux console and dumb terminals don't.
+func mouseSupported() bool {
+	switch os.Getenv("TERM") {
+	case "", "dumb", "linux":
+		return false
+	}
+	return true
+}
+
+// ansiPattern matches the escape sequences colors and styles render as.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
+// mouseKey translates a mouse event into the key doing the same: the wheel
+// moves the cursor, and clicking a PR moves the cursor onto it and presses
+// enter. Only single-choice pickers select on click, since enter confirms
+// every toggled PR of a multi-select.
+func (p *picker) mouseKey(msg tea.MouseMsg) (tea.KeyMsg, bool) {
+	if msg.Action != tea.MouseActionPress {
+		return tea.KeyMsg{}, false
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return tea.KeyMsg{Type: tea.KeyUp}, true
+	case tea.MouseButtonWheelDown:
+		return tea.KeyMsg{Type: tea.KeyDown}, true
+	case tea.MouseButtonLeft:
+		if p.filter == nil {
+			return tea.KeyMsg{}, false
+		}
+		number := rowAt(p.form.View(), lineCount(p.View()), msg.Y, p.height)
+		i := slices.IndexFunc(p.prs, func(pr PullRequest) bool { return pr.Number == number })
+		if number == 0 || i < 0 {
+			return tea.KeyMsg{}, false
+		}
+		p.applyFilter(i)
+		if p.hoveredIndex() != i {
+			return tea.KeyMsg{}, false
+		}
+		return tea.KeyMsg{Type: tea.KeyEnter}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+// rowAt is the number of the PR whose row of form is on screen row y, or
+// 0. The form is at the top of a view of total lines, which loses its top
+// lines when it is taller than the screen of height rows.
+func rowAt(form string, total, y, height int) int {
+	if height > 0 && total > height {
+		y += total - height
+	}
+	lines := strings.Split(form, "\n")
+	if y < 0 || y >= len(lines) {
+		return 0
+	}
+	// Rows start with the PR number, after the cursor on the hovered one
+	fields := strings.Fields(ansiPattern.ReplaceAllString(lines[y], ""))
+	for _, field := range fields[:min(2, len(fields))] {
+		if n, ok := strings.CutPrefix(field, "#"); ok {
+			if number, err := strconv.Atoi(n); err == nil {
+				return number
+			}
+		}
+	}
+	return 0
+}
+
+// lineCount is how many lines of the screen view takes.
+func lineCount(view string) int {
+	return strings.Count(view, "\n") + 1
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRowAt(t *testing.T) {
+	form := "Select a PR to checkout (3):\n  ID   TITLE\n> #12  Fix login\n  #7   Add \x1b[32m#3\x1b[0m support\n  #1   First"
+	tests := []struct {
+		name          string
+		total, height int
+		y             int
+		want          int
+	}{
+		{"hovered row", 8, 24, 2, 12},
+		{"other row", 8, 24, 3, 7},
+		{"title", 8, 24, 0, 0},
+		{"header", 8, 24, 1, 0},
+		{"below the form", 8, 24, 6, 0},
+		{"top lines cut off", 8, 6, 1, 7},
+		{"above the screen", 8, 6, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rowAt(form, tt.total, tt.y, tt.height); got != tt.want {
+				t.Errorf("rowAt() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMouseKey(t *testing.T) {
+	p := &picker{}
+	tests := []struct {
+		name   string
+		msg    tea.MouseMsg
+		want   tea.KeyType
+		wantOK bool
+	}{
+		{"wheel up", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelUp}, tea.KeyUp, true},
+		{"wheel down", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown}, tea.KeyDown, true},
+		{"release", tea.MouseMsg{Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft}, 0, false},
+		{"click in a multi-select", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonLeft}, 0, false},
+		{"right click", tea.MouseMsg{Action: tea.MouseActionPress, Button: tea.MouseButtonRight}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := p.mouseKey(tt.msg)
+			if ok != tt.wantOK || ok && got.Type != tt.want {
+				t.Errorf("mouseKey() = %v, %v, want %v, %v", got.Type, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
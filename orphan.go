@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// baseCheckWorkers limits how many base branches are looked up at once.
+const baseCheckWorkers = 8
+
+// orphanBaseFields are the gh pr list fields the base branch check needs.
+var orphanBaseFields = []string{"baseRefName"}
+
+// baseColumn shows the base branch, flagging it when it no longer exists.
+var baseColumn = column{
+	header:   "BASE",
+	maxWidth: 30,
+	value: func(pr PullRequest) string {
+		if pr.orphanBase {
+			return pr.BaseRefName + " (deleted)"
+		}
+		return pr.BaseRefName
+	},
+	style: func(pr PullRequest) lipgloss.Style {
+		if pr.orphanBase {
+			return redStyle
+		}
+		return grayStyle
+	},
+	fields: orphanBaseFields,
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"main", grayStyle, "base branch"},
+			{"main (deleted)", redStyle, "base branch no longer exists"},
+		}
+	},
+}
+
+// markOrphanBases sets orphanBase on the PRs whose base branch no longer
+// exists. Each distinct base is looked up once, concurrently.
+func markOrphanBases(prs []PullRequest) {
+	bases := map[string]bool{}
+	for _, pr := range prs {
+		bases[pr.BaseRefName] = false
+	}
+
+	names := make(chan string)
+	go func() {
+		for name := range bases {
+			names <- name
+		}
+		close(names)
+	}()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	missing := map[string]bool{}
+	for range baseCheckWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if !baseBranchExists(name) {
+					mu.Lock()
+					missing[name] = true
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range prs {
+		prs[i].orphanBase = missing[prs[i].BaseRefName]
+	}
+}
+
+// baseBranches caches baseBranchExists by branch name for the process
+// lifetime, so refreshing the list does not look up the same bases again.
+var baseBranches sync.Map
+
+// baseBranchExists reports whether the branch exists in the current
+// repository. Only a 404 counts as missing, so a failed lookup never hides
+// a PR; such lookups are not cached.
+func baseBranchExists(name string) bool {
+	if exists, ok := baseBranches.Load(name); ok {
+		return exists.(bool)
+	}
+	_, stderr, err := ghExec("api", "repos/{owner}/{repo}/branches/"+url.PathEscape(name), "--silent")
+	switch {
+	case err == nil:
+		baseBranches.Store(name, true)
+		return true
+	case strings.Contains(stderr.String(), "HTTP 404"):
+		baseBranches.Store(name, false)
+		return false
+	default:
+		return true
+	}
+}
+
+// withoutOrphanBases drops the PRs whose base branch no longer exists.
+func withoutOrphanBases(prs []PullRequest) []PullRequest {
+	kept := prs[:0]
+	for _, pr := range prs {
+		if !pr.orphanBase {
+			kept = append(kept, pr)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// writeJSON writes v to w, indented when pretty and on a single line
+// otherwise.
+func writeJSON(w io.Writer, v any, pretty bool) error {
+	var data []byte
+	var err error
+	if pretty {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// prettyJSON decides the --json layout: --json-pretty and --json-compact
+// force one, otherwise JSON is indented for a terminal and compact for pipes.
+func prettyJSON(f flags) bool {
+	switch {
+	case f.jsonPretty:
+		return true
+	case f.jsonCompact:
+		return false
+	default:
+		return term.IsTerminal(os.Stdout.Fd())
+	}
+}
+
+// jsonField describes one field --json can emit.
+type jsonField struct {
+	name string
+	typ  string
+}
+
+// jsonFields lists the fields of t as they appear in its JSON encoding.
+// Nested objects are flattened as "parent.child" and array elements as
+// "parent[].child". Fields without a json tag are not part of the output.
+func jsonFields(t reflect.Type, prefix string) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if !sf.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		name = prefix + name
+
+		ft := sf.Type
+		switch {
+		case ft == reflect.TypeOf(time.Time{}):
+			fields = append(fields, jsonField{name, "string (RFC 3339 time)"})
+		case ft.Kind() == reflect.Struct:
+			fields = append(fields, jsonFields(ft, name+".")...)
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			fields = append(fields, jsonFields(ft.Elem(), name+"[].")...)
+		default:
+			fields = append(fields, jsonField{name, jsonType(ft)})
+		}
+	}
+	return fields
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array of " + jsonType(t.Elem())
+	default:
+		return t.Kind().String()
+	}
+}
+
+// printJSONHelp describes the fields --json can emit. It is derived from
+// PullRequest so it can't drift from what is actually decoded.
+func printJSONHelp(w io.Writer) {
+	fields := jsonFields(reflect.TypeOf(PullRequest{}), "")
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.name))
+	}
+
+	fmt.Fprintln(w, "--json prints the selected PR with the fields gh po fetched for it.")
+	fmt.Fprintln(w, "Fields marked * are always present; the others depend on the flags")
+	fmt.Fprintln(w, "and columns in use.")
+	fmt.Fprintln(w)
+	for _, f := range fields {
+		mark := " "
+		top, _, _ := strings.Cut(f.name, ".")
+		if slices.Contains(baseFields, strings.TrimSuffix(top, "[]")) {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %-*s  %s\n", mark, width, f.name, f.typ)
+	}
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// uiOutput is where the picker renders. It is stderr when stdout carries
+// machine-readable output.
+var uiOutput io.Writer = os.Stdout
+
+// pickerHints shows the picker's keys below the form (disabled by --no-help).
+var pickerHints = true
+
+// toastDuration is how long a picker key's feedback stays visible.
+const toastDuration = 2 * time.Second
+
+// prField is the huh field listing the PRs. Its option values are indexes
+// into the picker's PRs; negative values are not PRs.
+type prField interface {
+	Hovered() (int, bool)
+	GetFiltering() bool
+}
+
+// picker runs a huh form inside its own bubbletea program so that keys the
+// form does not know about can be handled around it.
+type picker struct {
+	form       *huh.Form
+	legend     string
+	showLegend bool
+	// footer sums up the listed PRs below the form
+	footer string
+
+	field prField
+	prs   []PullRequest
+
+	// filter replaces huh's filtering for single-choice pickers. query is
+	// typed while filtering is set and kept afterwards.
+	filter    *selectFilter
+	filtering bool
+	query     string
+
+	// preview shows the hovered PR's body in viewport. bodies caches the
+	// rendered bodies by PR number and fetching marks those on their way,
+	// so moving the cursor back and forth fetches each body once.
+	preview   bool
+	viewport  viewport.Model
+	previewed int
+	bodies    map[int]string
+	fetching  map[int]bool
+
+	toast   string
+	toastID int
+
+	// height is the height of the screen, once known
+	height int
+}
+
+// selectFilter is what the picker needs to filter a Select itself: huh
+// matches the typed text against the option labels, whose color codes and
+// padding get in the way.
+type selectFilter struct {
+	field *huh.Select[int]
+	// value is the field's value; setting it before replacing the options
+	// keeps the cursor on that PR
+	value   *int
+	options []huh.Option[int]
+	// cols lets s sort the rows live when set; the options are rebuilt from
+	// them in the new order
+	cols []column
+}
+
+// toastMsg shows text below the form; clearToastMsg hides it again unless
+// a newer toast replaced it.
+type (
+	toastMsg      string
+	clearToastMsg int
+)
+
+// runPicker runs form until it is submitted or aborted. It returns
+// huh.ErrUserAborted if the form was not submitted. Besides the form's own
+// keys, the picker handles:
+//
+//	?  toggle the legend below the form
+//	o  open the PR under the cursor in the browser (also ctrl+o)
+//	y  copy the number of the PR under the cursor, e.g. "#42"
+//	Y  copy the URL of the PR under the cursor
+//	/  filter by title, branch and number (with a non-nil filter)
+//	s  cycle the sort order (with a filter that has cols)
+//	p  toggle the preview of the PR's body below the form
+//	J  scroll the preview down; K scrolls it up
+//
+// None of them apply while a filter is being typed. With mouseEnabled the
+// wheel moves the cursor and clicking a PR selects it; the picker then
+// takes the whole screen, whose rows the clicks are reported in.
+func runPicker(form *huh.Form, legend string, field prField, prs []PullRequest, cols []column, filter *selectFilter) error {
+	form.SubmitCmd = tea.Quit
+	form.CancelCmd = tea.Quit
+	// The hints replace huh's help, which doesn't know the picker's own keys
+	form.WithShowHelp(false)
+
+	width := tableWidth
+	if width <= 0 {
+		width = defaultPreviewWidth
+	}
+	p := &picker{
+		form: form, legend: legend, field: field, prs: prs, filter: filter,
+		footer:   prCounts(prs, cols),
+		preview:  previewPane,
+		viewport: viewport.New(width, previewHeight),
+		bodies:   map[int]string{},
+		fetching: map[int]bool{},
+	}
+	opts := []tea.ProgramOption{tea.WithOutput(uiOutput)}
+	if mouseEnabled {
+		opts = append(opts, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	}
+	if _, err := tea.NewProgram(p, opts...).Run(); err != nil {
+		return err
+	}
+	if form.State != huh.StateCompleted {
+		return huh.ErrUserAborted
+	}
+	return nil
+}
+
+func (p *picker) Init() tea.Cmd {
+	return tea.Batch(p.form.Init(), p.syncPreview())
+}
+
+func (p *picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case toastMsg:
+		p.toastID++
+		p.toast = string(msg)
+		id := p.toastID
+		return p, tea.Tick(toastDuration, func(time.Time) tea.Msg { return clearToastMsg(id) })
+	case clearToastMsg:
+		if int(msg) == p.toastID {
+			p.toast = ""
+		}
+		return p, nil
+	case bodyMsg:
+		delete(p.fetching, msg.number)
+		p.bodies[msg.number] = renderBody(msg, p.viewport.Width)
+		if msg.number == p.previewed {
+			p.viewport.SetContent(p.bodies[msg.number])
+		}
+		return p, nil
+	case tea.WindowSizeMsg:
+		p.height = msg.Height
+		// Leave room for the lines below the form, so that huh scrolls the
+		// rows under the title and header instead of pushing them off screen
+		msg.Height = max(1, msg.Height-(lineCount(p.View())-lineCount(p.form.View())))
+		return p.updateForm(msg)
+	case tea.MouseMsg:
+		if key, ok := p.mouseKey(msg); ok {
+			return p.Update(key)
+		}
+		return p, nil
+	case tea.KeyMsg:
+		if p.filtering {
+			if p.handleFilterKey(msg) {
+				return p, nil
+			}
+		} else if !p.field.GetFiltering() {
+			if cmd, ok := p.handleKey(msg.String()); ok {
+				return p, cmd
+			}
+		}
+	}
+	return p.updateForm(msg)
+}
+
+// updateForm passes msg on to the form.
+func (p *picker) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := p.form.Update(msg)
+	if form, ok := model.(*huh.Form); ok {
+		p.form = form
+	}
+	return p, tea.Batch(cmd, p.syncPreview())
+}
+
+// handleKey runs the picker's own keys and reports whether key was one.
+func (p *picker) handleKey(key string) (tea.Cmd, bool) {
+	if key == "?" {
+		p.showLegend = !p.showLegend
+		return nil, true
+	}
+	switch key {
+	case "p":
+		p.preview = !p.preview
+		p.previewed = 0
+		return p.syncPreview(), true
+	case "J", "K":
+		if !p.preview {
+			return nil, false
+		}
+		offset := p.viewport.YOffset + 1
+		if key == "K" {
+			offset = p.viewport.YOffset - 1
+		}
+		p.viewport.SetYOffset(offset)
+		return nil, true
+	}
+	if p.filter != nil && p.filter.cols != nil && key == "s" {
+		p.resort(nextLiveSort(activeSort))
+		return nil, true
+	}
+	if p.filter != nil && key == "/" {
+		p.filtering = true
+		return nil, true
+	}
+	if p.filter != nil && key == "esc" && p.query != "" {
+		p.query = ""
+		p.applyFilter(p.hoveredIndex())
+		return nil, true
+	}
+	if key == "ctrl+o" {
+		key = "o"
+	}
+	if key != "o" && key != "y" && key != "Y" {
+		return nil, false
+	}
+
+	i, ok := p.field.Hovered()
+	if !ok || i < 0 || i >= len(p.prs) {
+		return nil, true
+	}
+	pr := p.prs[i]
+	switch key {
+	case "o":
+		// --dry-run: the picker keeps stdout, so the command is shown
+		// below it instead of printed
+		if dryRun {
+			return func() tea.Msg { return toastMsg(grayStyle.Render(commandLine(browseArgs(pr)...))) }, true
+		}
+		return func() tea.Msg {
+			// Run in the background with no stdio, the picker keeps the
+			// terminal and stays as it is
+			_, run := browseCommand(pr)
+			if err := run(); err != nil {
+				return toastMsg(redStyle.Render("✗ ") + fmt.Sprintf("failed to open PR #%d: %v", pr.Number, err))
+			}
+			return toastMsg(greenStyle.Render("✓ ") + fmt.Sprintf("Opened #%d in browser", pr.Number))
+		}, true
+	case "y":
+		return copyCmd(fmt.Sprintf("#%d", pr.Number)), true
+	default:
+		if pr.URL == "" {
+			return nil, true
+		}
+		return copyCmd(pr.URL), true
+	}
+}
+
+// handleFilterKey edits the filter query and reports whether key was
+// consumed. Keys it doesn't consume, e.g. the arrows, go to the form.
+func (p *picker) handleFilterKey(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		p.query += string(msg.Runes)
+	case tea.KeySpace:
+		p.query += " "
+	case tea.KeyBackspace:
+		runes := []rune(p.query)
+		if len(runes) == 0 {
+			return true
+		}
+		p.query = string(runes[:len(runes)-1])
+	case tea.KeyEsc:
+		p.filtering = false
+		return true
+	case tea.KeyEnter:
+		p.filtering = false
+		// Without a match there is nothing to choose
+		_, ok := p.field.Hovered()
+		return !ok
+	default:
+		return false
+	}
+	p.applyFilter(p.hoveredIndex())
+	return true
+}
+
+// hoveredIndex is the index into prs of the PR under the cursor, or -1.
+func (p *picker) hoveredIndex() int {
+	if i, ok := p.field.Hovered(); ok {
+		return i
+	}
+	return -1
+}
+
+// resort lists the PRs in order and rebuilds the options, keeping the
+// cursor and the filter. The PRs are sorted in place, so the form's value
+// indexes them in their new order.
+func (p *picker) resort(order string) {
+	number := 0
+	if i := p.hoveredIndex(); i >= 0 && i < len(p.prs) {
+		number = p.prs[i].Number
+	}
+	activeSort = order
+	sortPRs(p.prs, order, reverseSort)
+
+	options, header := buildOptions(p.prs, p.filter.cols)
+	p.filter.options = options
+	p.filter.field.Description(header)
+	p.applyFilter(slices.IndexFunc(p.prs, func(pr PullRequest) bool { return pr.Number == number }))
+}
+
+// applyFilter shows the options whose PR matches the query, keeping the
+// cursor on the PR at index hovered if it is still shown. Options that
+// aren't PRs, such as bucket headers, are only shown without a query.
+func (p *picker) applyFilter(hovered int) {
+	var options []huh.Option[int]
+	for _, o := range p.filter.options {
+		if p.query == "" || o.Value >= 0 && matchesFilter(p.prs[o.Value], p.query) {
+			options = append(options, o)
+		}
+	}
+	if len(options) > 0 {
+		*p.filter.value = options[0].Value
+		for _, o := range options {
+			if o.Value == hovered {
+				*p.filter.value = hovered
+			}
+		}
+	}
+	p.filter.field.Options(options...)
+}
+
+// matchesFilter reports whether every word of query is found, ignoring
+// case, in the title, the head branch or the "#number" of pr.
+func matchesFilter(pr PullRequest, query string) bool {
+	fields := []string{strings.ToLower(pr.Title), strings.ToLower(pr.HeadRefName), "#" + strconv.Itoa(pr.Number)}
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		found := false
+		for _, field := range fields {
+			if strings.Contains(field, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// copyCmd copies text to the clipboard and reports the outcome as a toast.
+func copyCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err != nil {
+			return toastMsg(redStyle.Render("✗ ") + "failed to copy: " + err.Error())
+		}
+		return toastMsg(greenStyle.Render("✓ ") + "Copied " + text)
+	}
+}
+
+func (p *picker) View() string {
+	if p.form.State != huh.StateNormal {
+		return ""
+	}
+	view := p.form.View()
+	view += "\n" + grayStyle.Render(p.footer) + "\n"
+	if p.filtering || p.query != "" {
+		view += "\n" + cyanStyle.Render("/ ") + p.query
+		if p.filtering {
+			view += "█"
+		}
+		view += "\n"
+	}
+	if p.toast != "" {
+		view += "\n" + p.toast + "\n"
+	}
+	if p.preview {
+		view += "\n" + p.previewView() + "\n"
+	}
+	if p.showLegend {
+		view += "\n" + p.legend + "\n"
+	}
+	if pickerHints {
+		view += "\n" + grayStyle.Render(p.hints()) + "\n"
+	}
+	return view
+}
+
+// prCounts sums up prs in one line, e.g. "12 PRs · 3 drafts · 9 ready",
+// adding how many fail their checks when cols show them.
+func prCounts(prs []PullRequest, cols []column) string {
+	drafts, failing := 0, 0
+	for _, pr := range prs {
+		if pr.IsDraft {
+			drafts++
+		}
+		if checksOf(pr.StatusCheckRollup) == checksFailing {
+			failing++
+		}
+	}
+	counts := []string{countOf(len(prs), "PR", "PRs"), countOf(drafts, "draft", "drafts"), fmt.Sprintf("%d ready", len(prs)-drafts)}
+	if slices.ContainsFunc(cols, func(col column) bool { return col.name == "checks" }) {
+		counts = append(counts, fmt.Sprintf("%d failing", failing))
+	}
+	return strings.Join(counts, " · ")
+}
+
+// countOf is n followed by the singular or plural noun, e.g. "1 PR".
+func countOf(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// hints lists the keys that currently do something, e.g.
+// "↑/↓ move · enter select · / filter · o browse · y/Y copy · ctrl+c quit".
+func (p *picker) hints() string {
+	if p.filtering {
+		return "type to filter by title, branch or #number · enter select · esc done · ctrl+c quit"
+	}
+	if p.field.GetFiltering() {
+		return "type to filter · esc done · ctrl+c quit"
+	}
+	keys := []string{"↑/↓ move"}
+	if _, multi := p.field.(*huh.MultiSelect[int]); multi {
+		keys = append(keys, "x toggle", "enter confirm")
+	} else {
+		keys = append(keys, "enter select")
+	}
+	keys = append(keys, "/ filter")
+	if p.filter != nil && p.filter.cols != nil {
+		order := activeSort
+		if order == "" {
+			order = "newest"
+		}
+		if reverseSort {
+			order += ", reversed"
+		}
+		keys = append(keys, "s sort: "+order)
+	}
+	if p.query != "" {
+		keys = append(keys, "esc clear filter")
+	}
+	keys = append(keys, "o browse", "y/Y copy", "p preview")
+	if p.preview {
+		keys = append(keys, "J/K scroll preview")
+	}
+	if p.legend != "" {
+		keys = append(keys, "? legend")
+	}
+	keys = append(keys, "ctrl+c quit")
+	return strings.Join(keys, " · ")
+}
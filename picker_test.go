@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestPRCounts(t *testing.T) {
+	failed := []checkRun{{Conclusion: "FAILURE"}}
+	tests := []struct {
+		name string
+		prs  []PullRequest
+		cols []column
+		want string
+	}{
+		{"no PRs", nil, defaultColumns, "0 PRs · 0 drafts · 0 ready"},
+		{"one PR", []PullRequest{{IsDraft: true}}, defaultColumns, "1 PR · 1 draft · 0 ready"},
+		{
+			"many PRs",
+			[]PullRequest{{IsDraft: true}, {}, {StatusCheckRollup: failed}, {IsDraft: true}},
+			defaultColumns,
+			"4 PRs · 2 drafts · 2 ready",
+		},
+		{
+			"failing checks with the CI column",
+			[]PullRequest{{IsDraft: true, StatusCheckRollup: failed}, {}, {StatusCheckRollup: failed}},
+			tableColumns([]string{"checks"}, false),
+			"3 PRs · 1 draft · 2 ready · 2 failing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prCounts(tt.prs, tt.cols); got != tt.want {
+				t.Errorf("prCounts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errNoTerminal is reported when the picker is needed but stdout is not a
+// terminal, e.g. in a pipe or on CI.
+var errNoTerminal = errors.New("stdout is not a terminal, so no picker can be shown; pass a PR number or head branch to select it")
+
+// printTable writes prs as aligned text with the picker's columns, for
+// --plain and output that isn't a terminal.
+func printTable(w io.Writer, prs []PullRequest, cols []column) {
+	widths := columnWidths(prs, cols)
+	fmt.Fprintln(w, buildHeader(cols, widths))
+	for _, pr := range prs {
+		fmt.Fprintln(w, "  "+formatPR(pr, cols, widths))
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintTable(t *testing.T) {
+	cols := []column{idColumn, titleColumn, branchColumn}
+	prs := []PullRequest{
+		{Number: 12, Title: "Fix login", HeadRefName: "fix-login"},
+		{Number: 3, Title: "ドキュメント", HeadRefName: "docs", IsDraft: true},
+	}
+	var buf bytes.Buffer
+	printTable(&buf, prs, cols)
+	want := "" +
+		"  ID   TITLE         BRANCH   \n" +
+		"  #12  Fix login     fix-login\n" +
+		"  #3   ドキュメント  docs     \n"
+	if buf.String() != want {
+		t.Errorf("printTable() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintTableKeepsFullTitle(t *testing.T) {
+	title := strings.Repeat("A very long title ", 20) + "ログイン画面を修正"
+	prs := []PullRequest{{Number: 1, Title: title, HeadRefName: strings.Repeat("b", 40)}}
+	var buf bytes.Buffer
+	printTable(&buf, prs, tableColumns(nil, true))
+	if !strings.Contains(buf.String(), title) || !strings.Contains(buf.String(), prs[0].HeadRefName) {
+		t.Errorf("printTable() = %s, want the full title and branch", buf.String())
+	}
+}
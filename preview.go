@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// previewPane shows the body of the hovered PR below the picker (--preview).
+var previewPane bool
+
+// previewHeight is how many lines of a body the preview pane shows at once.
+const previewHeight = 10
+
+// defaultPreviewWidth is the width of the preview pane when the terminal's
+// is unknown.
+const defaultPreviewWidth = 80
+
+type bodyMsg struct {
+	number int
+	body   string
+	err    error
+}
+
+func fetchBody(number int) tea.Cmd {
+	return func() tea.Msg {
+		stdout, stderr, err := ghExec("pr", "view", strconv.Itoa(number), "--json", "body", "-q", ".body")
+		if err != nil {
+			return bodyMsg{number: number, err: fmt.Errorf("failed to fetch PR #%d: %s", number, strings.TrimSpace(stderr.String()))}
+		}
+		return bodyMsg{number: number, body: stdout.String()}
+	}
+}
+
+// renderBody renders a fetched body for the preview pane, wrapped to width.
+func renderBody(msg bodyMsg, width int) string {
+	if msg.err != nil {
+		return redStyle.Render(msg.err.Error())
+	}
+	body := strings.TrimSpace(msg.body)
+	if body == "" {
+		return grayStyle.Render("No description provided.")
+	}
+	return renderMarkdown(body, width)
+}
+
+// syncPreview shows the body of the hovered PR in the preview pane, starting
+// to fetch it if it isn't cached yet.
+func (p *picker) syncPreview() tea.Cmd {
+	if !p.preview {
+		return nil
+	}
+	i, ok := p.field.Hovered()
+	if !ok || i < 0 || i >= len(p.prs) || p.prs[i].Number == p.previewed {
+		return nil
+	}
+	number := p.prs[i].Number
+	p.previewed = number
+	p.viewport.GotoTop()
+	if body, ok := p.bodies[number]; ok {
+		p.viewport.SetContent(body)
+		return nil
+	}
+	p.viewport.SetContent(grayStyle.Render("Loading..."))
+	if p.fetching[number] {
+		return nil
+	}
+	p.fetching[number] = true
+	return fetchBody(number)
+}
+
+// previewView is the pane below the form: a rule naming the PR and the
+// visible part of its body.
+func (p *picker) previewView() string {
+	if p.previewed == 0 {
+		return ""
+	}
+	label := fmt.Sprintf("─ #%d ", p.previewed)
+	if p.viewport.TotalLineCount() > previewHeight {
+		label += fmt.Sprintf("%.f%% ", p.viewport.ScrollPercent()*100)
+	}
+	rule := label + strings.Repeat("─", max(0, p.viewport.Width-len([]rune(label))))
+	return grayStyle.Render(rule) + "\n" + p.viewport.View()
+}
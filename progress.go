@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh/spinner"
+)
+
+// progressModes are the accepted --progress values.
+var progressModes = []string{"spinner", "dots", "none"}
+
+// progressMode is how runWithProgress shows that work is going on
+// (--progress).
+var progressMode = "spinner"
+
+// dotInterval is how often a dot is added in the dots mode.
+const dotInterval = 500 * time.Millisecond
+
+// progressTitle is the title runWithProgress shows while its action runs.
+var progressTitle atomic.Pointer[string]
+
+// runWithProgress runs action while showing title: next to the animated
+// spinner on uiOutput, followed by a growing line of dots on stderr, or not
+// at all. The action can change the title with setProgressTitle.
+func runWithProgress(title string, action func()) {
+	progressTitle.Store(&title)
+	switch progressMode {
+	case "none":
+		action()
+	case "dots":
+		fmt.Fprint(os.Stderr, title)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			action()
+		}()
+		ticker := time.NewTicker(dotInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				fmt.Fprintln(os.Stderr)
+				return
+			case <-ticker.C:
+				fmt.Fprint(os.Stderr, ".")
+			}
+		}
+	default:
+		// The spinner draws on stdout by default, which --json keeps clean
+		s := titledSpinner{spinner.New().Title("").Action(action)}
+		_, _ = tea.NewProgram(s, tea.WithOutput(uiOutput), tea.WithInput(nil)).Run()
+	}
+}
+
+// setProgressTitle replaces the title of the running runWithProgress. With
+// dots, the new title starts a new line.
+func setProgressTitle(title string) {
+	progressTitle.Store(&title)
+	if progressMode == "dots" {
+		fmt.Fprint(os.Stderr, "\n"+title)
+	}
+}
+
+// titledSpinner is a huh spinner showing progressTitle, which huh's own
+// title can't follow once the spinner runs.
+type titledSpinner struct {
+	*spinner.Spinner
+}
+
+func (s titledSpinner) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := s.Spinner.Update(msg)
+	return s, cmd
+}
+
+func (s titledSpinner) View() string {
+	return s.Spinner.View() + *progressTitle.Load()
+}
+
+// defaultProgressMode picks dots on terminals that can't redraw a line,
+// where the spinner's frames would pile up.
+func defaultProgressMode() string {
+	if os.Getenv("TERM") == "dumb" {
+		return "dots"
+	}
+	return "spinner"
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectItemsQuery pages through the items of a ProjectV2 owned by the
+// repository owner, with each item's Status field.
+const projectItemsQuery = `
+query($owner: String!, $number: Int!, $endCursor: String) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        items(first: 100, after: $endCursor) {
+          nodes {
+            content {
+              ... on PullRequest { number repository { nameWithOwner } }
+            }
+            fieldValueByName(name: "Status") {
+              ... on ProjectV2ItemFieldSingleSelectValue { name }
+            }
+          }
+          pageInfo { hasNextPage endCursor }
+        }
+      }
+    }
+  }
+}`
+
+// projectItemsJQ flattens every page to one JSON object per pull request.
+const projectItemsJQ = `.data.repositoryOwner.projectV2.items.nodes[]
+| select(.content.number != null)
+| {repo: .content.repository.nameWithOwner, number: .content.number, status: (.fieldValueByName.name // "")}`
+
+// projectItem is a pull request on a project board.
+type projectItem struct {
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	Status string `json:"status"`
+}
+
+// statusColumn shows the Status of the PR on the --project board.
+var statusColumn = column{
+	name:     "status",
+	header:   "STATUS",
+	maxWidth: 20,
+	value: func(pr PullRequest) string {
+		if pr.projectStatus == "" {
+			return "-"
+		}
+		return pr.projectStatus
+	},
+	style: func(PullRequest) lipgloss.Style { return magentaStyle },
+	legend: func() []legendEntry {
+		return []legendEntry{{"In review", magentaStyle, "status on the project board"}}
+	},
+}
+
+// projectItems returns the pull requests on project number of owner.
+// Reading projects needs the read:project token scope.
+func projectItems(owner string, number int) ([]projectItem, error) {
+	stdout, stderr, err := ghExec("api", "graphql", "--paginate",
+		"-f", "query="+projectItemsQuery,
+		"-F", "owner="+owner,
+		"-F", "number="+strconv.Itoa(number),
+		"--jq", projectItemsJQ)
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "read:project") {
+			msg += "\nrun `gh auth refresh -s read:project` to grant access to projects"
+		}
+		return nil, fmt.Errorf("failed to read project %d of %s: %s", number, owner, msg)
+	}
+
+	var items []projectItem
+	dec := json.NewDecoder(&stdout)
+	for {
+		var item projectItem
+		if err := dec.Decode(&item); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse project items: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// filterByProject keeps the PRs of repo that are on the board, setting
+// their projectStatus. A non-empty status keeps only the PRs in that
+// status, compared case-insensitively.
+func filterByProject(prs []PullRequest, items []projectItem, repo, status string) []PullRequest {
+	statuses := map[int]string{}
+	for _, item := range items {
+		if strings.EqualFold(item.Repo, repo) {
+			statuses[item.Number] = item.Status
+		}
+	}
+
+	var kept []PullRequest
+	for _, pr := range prs {
+		s, ok := statuses[pr.Number]
+		if !ok || (status != "" && !strings.EqualFold(s, status)) {
+			continue
+		}
+		pr.projectStatus = s
+		kept = append(kept, pr)
+	}
+	return kept
+}
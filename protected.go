@@ -0,0 +1,18 @@
+package main
+
+import "path"
+
+// defaultProtectedBranches are head branch patterns that are refused for
+// checkout without --yes, since they are usually shared.
+var defaultProtectedBranches = []string{"main", "master", "develop"}
+
+// protectedPattern returns the first of patterns matching branch, using
+// path.Match syntax so "release/*" covers every release branch.
+func protectedPattern(branch string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestProtectedPattern(t *testing.T) {
+	tests := []struct {
+		branch   string
+		patterns []string
+		want     string
+		ok       bool
+	}{
+		{"main", defaultProtectedBranches, "main", true},
+		{"master", defaultProtectedBranches, "master", true},
+		{"develop", defaultProtectedBranches, "develop", true},
+		{"feature/main", defaultProtectedBranches, "", false},
+		{"mainline", defaultProtectedBranches, "", false},
+		{"release/1.2", []string{"main", "release/*"}, "release/*", true},
+		{"release/1.2/hotfix", []string{"release/*"}, "", false},
+		{"release", []string{"release/*"}, "", false},
+		{"release/1.2", []string{"release/1.2", "release/*"}, "release/1.2", true},
+		{"feature/x", []string{"main", "release/*"}, "", false},
+		{"main", nil, "", false},
+		{"main", []string{"[", "ma*"}, "ma*", true},
+		{"[", []string{"["}, "", false},
+		{"main", []string{"ma[in"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := protectedPattern(tt.branch, tt.patterns)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("protectedPattern(%q, %q) = %q, %v, want %q, %v", tt.branch, tt.patterns, got, ok, tt.want, tt.ok)
+		}
+	}
+}
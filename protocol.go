@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// remoteURL is a parsed git remote URL.
+type remoteURL struct {
+	protocol string // "ssh" or "https"
+	host     string
+	repo     string // owner/name
+}
+
+// parseRemoteURL understands https://host/owner/name(.git),
+// ssh://git@host/owner/name(.git) and git@host:owner/name(.git).
+func parseRemoteURL(raw string) (remoteURL, bool) {
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return remoteURL{}, false
+		}
+		protocol := u.Scheme
+		if protocol != "ssh" && protocol != "https" {
+			return remoteURL{}, false
+		}
+		return remoteURL{protocol, u.Hostname(), trimRepoPath(u.Path)}, true
+	}
+
+	// scp-like syntax: [user@]host:owner/name
+	hostPart, path, ok := strings.Cut(raw, ":")
+	if !ok {
+		return remoteURL{}, false
+	}
+	if _, host, ok := strings.Cut(hostPart, "@"); ok {
+		hostPart = host
+	}
+	return remoteURL{"ssh", hostPart, trimRepoPath(path)}, true
+}
+
+func trimRepoPath(path string) string {
+	return strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+}
+
+// String formats the URL the way gh clones with each protocol.
+func (r remoteURL) String() string {
+	if r.protocol == "ssh" {
+		return fmt.Sprintf("git@%s:%s.git", r.host, r.repo)
+	}
+	return fmt.Sprintf("https://%s/%s.git", r.host, r.repo)
+}
+
+// ensureRemoteProtocol makes the git remote of the current repository use
+// protocol, rewriting its URL if needed, so gh pr checkout fetches with it.
+func ensureRemoteProtocol(protocol string) error {
+	stdout, stderr, err := repoView("--json", "nameWithOwner,url")
+	if err != nil {
+		return fmt.Errorf("failed to resolve repository: %s", strings.TrimSpace(stderr.String()))
+	}
+	var repo struct {
+		NameWithOwner string `json:"nameWithOwner"`
+		URL           string `json:"url"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &repo); err != nil {
+		return fmt.Errorf("failed to parse repository: %w", err)
+	}
+	u, err := url.Parse(repo.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse repository URL %q: %w", repo.URL, err)
+	}
+
+	out, errOut, err := runGit("remote", "-v")
+	if err != nil {
+		return fmt.Errorf("failed to list git remotes: %s", strings.TrimSpace(errOut))
+	}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[2] != "(fetch)" {
+			continue
+		}
+		name, remote := fields[0], fields[1]
+		parsed, ok := parseRemoteURL(remote)
+		if !ok || !strings.EqualFold(parsed.host, u.Hostname()) || !strings.EqualFold(parsed.repo, repo.NameWithOwner) {
+			continue
+		}
+		if parsed.protocol == protocol {
+			return nil
+		}
+
+		parsed.protocol = protocol
+		if _, errOut, err := runGit("remote", "set-url", name, parsed.String()); err != nil {
+			return fmt.Errorf("failed to switch remote %s to %s: %s", name, protocol, strings.TrimSpace(errOut))
+		}
+		fmt.Println(grayStyle.Render(fmt.Sprintf("Switched remote %s to %s: %s", name, protocol, parsed)))
+		return nil
+	}
+
+	return fmt.Errorf("no git remote points to %s; cannot use --protocol %s", repo.NameWithOwner, protocol)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// forceCheckout passes --force to gh pr checkout (--force), which resets a
+// local branch that diverged from the PR, e.g. after a force-push.
+var forceCheckout bool
+
+// checkoutArgs are the gh arguments checking out pr, into a local branch
+// called branch if it is not empty.
+func checkoutArgs(pr PullRequest, branch string) []string {
+	args := []string{"pr", "checkout", strconv.Itoa(pr.Number)}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	if forceCheckout {
+		args = append(args, "--force")
+	}
+	return args
+}
+
+// recreateBranch deletes name, the local branch pr is checked out into, so
+// that checking out starts it over (--recreate). It asks first unless yes
+// is set, and reports false if that was declined. A missing branch is
+// nothing to delete.
+func recreateBranch(pr PullRequest, name string, yes bool) (bool, error) {
+	if _, _, err := runGit("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err != nil {
+		return true, nil
+	}
+	// git refuses to delete the branch HEAD is on
+	if current, _ := currentBranch(); current == name {
+		return false, fmt.Errorf("branch %q is checked out; switch to another branch to recreate it, or use --force", name)
+	}
+	if !yes {
+		confirmed := false
+		err := newForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Delete the local branch %s and check out PR #%d again?", name, pr.Number)).
+					Description("Commits that are only on the local branch are lost.").
+					Value(&confirmed),
+			),
+		).Run()
+		if err != nil || !confirmed {
+			return false, nil
+		}
+	}
+
+	var stdoutStr, stderrStr string
+	var execErr error
+	runWithProgress("Deleting local branch...", func() {
+		stdoutStr, stderrStr, execErr = runGit("branch", "-D", name)
+	})
+	if execErr != nil {
+		return false, fmt.Errorf("failed to delete branch %q: %s", name, strings.TrimSpace(stderrStr))
+	}
+	// git names the branch and the commit it was at, e.g.
+	// "Deleted branch fix (was 1a2b3c4)."
+	fmt.Print(stdoutStr)
+	return true, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckoutArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		force  bool
+		want   []string
+	}{
+		{"plain", "", false, []string{"pr", "checkout", "12"}},
+		{"branch", "review/fix", false, []string{"pr", "checkout", "12", "--branch", "review/fix"}},
+		{"force", "", true, []string{"pr", "checkout", "12", "--force"}},
+		{"branch and force", "review/fix", true, []string{"pr", "checkout", "12", "--branch", "review/fix", "--force"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forceCheckout = tt.force
+			t.Cleanup(func() { forceCheckout = false })
+			if got := checkoutArgs(PullRequest{Number: 12}, tt.branch); !slices.Equal(got, tt.want) {
+				t.Errorf("checkoutArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/huh"
+)
+
+// reopenPR reopens the closed pr after a confirmation. gh's output and
+// errors are shown as they are.
+func reopenPR(pr PullRequest) error {
+	confirmed := false
+	err := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Reopen PR #%d %s?", pr.Number, pr.Title)).
+				Value(&confirmed),
+		),
+	).Run()
+	if err != nil || !confirmed {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	var stdoutStr, stderrStr string
+	var execErr error
+
+	runWithProgress(fmt.Sprintf("Reopening PR #%d...", pr.Number), func() {
+		stdout, stderr, err := ghExec("pr", "reopen", strconv.Itoa(pr.Number))
+		stdoutStr = stdout.String()
+		stderrStr = stderr.String()
+		execErr = err
+	})
+
+	if stdoutStr != "" {
+		fmt.Print(stdoutStr)
+	}
+	if stderrStr != "" {
+		fmt.Print(stderrStr)
+	}
+	if execErr != nil {
+		return fmt.Errorf("failed to reopen PR #%d: %w", pr.Number, execErr)
+	}
+	audit.record(pr, "reopen")
+	return nil
+}
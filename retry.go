@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultRetries is how often a transient gh failure is retried without
+// --retries.
+const defaultRetries = 2
+
+// ghRetries is how often a gh call failing for a transient reason is
+// retried (--retries). 0 gives up on the first failure.
+var ghRetries = defaultRetries
+
+// retryDelay is the wait before the first retry; it doubles for each one
+// after that.
+var retryDelay = time.Second
+
+// transientMarkers are parts of gh's error messages for failures that may
+// pass by themselves: server errors, network blips and rate limits. gh's
+// own timeouts are not among them, so --timeout stays the upper bound.
+var transientMarkers = []string{
+	"HTTP 500", "HTTP 502", "HTTP 503", "HTTP 504",
+	"rate limit", "connection reset", "connection refused", "i/o timeout",
+	"TLS handshake timeout", "no such host", "unexpected EOF",
+}
+
+// permanentMarkers are parts of gh's error messages that a retry can't fix,
+// such as missing authentication or not being in a repository. They win
+// over transientMarkers.
+var permanentMarkers = []string{
+	"HTTP 401", "HTTP 403: Resource not accessible", "HTTP 404", "gh auth login",
+	"not a git repository", "Could not resolve to a Repository", "none of the git remotes",
+}
+
+// isTransient reports whether gh's stderr tells of a failure worth
+// retrying.
+func isTransient(stderr string) bool {
+	contains := func(marker string) bool { return strings.Contains(stderr, marker) }
+	for _, marker := range permanentMarkers {
+		if contains(marker) {
+			return false
+		}
+	}
+	for _, marker := range transientMarkers {
+		if contains(marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetries runs a gh call until it succeeds, fails for a reason that
+// isn't transient, or has been retried ghRetries times, waiting twice as
+// long before each retry. The progress title tells that it is retrying.
+func withRetries(call func() (bytes.Buffer, bytes.Buffer, error)) (bytes.Buffer, bytes.Buffer, error) {
+	delay := retryDelay
+	for retry := 1; ; retry++ {
+		stdout, stderr, err := call()
+		if err == nil || retry > ghRetries || !isTransient(stderr.String()) {
+			return stdout, stderr, err
+		}
+		setProgressTitle(fmt.Sprintf("gh failed, retrying (%d/%d)...", retry, ghRetries))
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   bool
+	}{
+		{"HTTP 502: Bad Gateway (https://api.github.com/graphql)", true},
+		{"HTTP 503: Service Unavailable", true},
+		{"API rate limit exceeded for user ID 1", true},
+		{"Post \"https://api.github.com/graphql\": read tcp: connection reset by peer", true},
+		{"dial tcp: lookup api.github.com: no such host", true},
+		{"HTTP 401: Bad credentials (https://api.github.com/graphql)\nTry authenticating with:  gh auth login", false},
+		{"To get started with GitHub CLI, please run:  gh auth login", false},
+		{"failed to run git: fatal: not a git repository (or any of the parent directories): .git", false},
+		{"GraphQL: Could not resolve to a Repository with the name 'owner/nope'.", false},
+		{"gh timed out after 30s", false},
+		{"invalid search query", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isTransient(tt.stderr); got != tt.want {
+			t.Errorf("isTransient(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	delay, mode := retryDelay, progressMode
+	retryDelay, progressMode = 0, "none"
+	t.Cleanup(func() { retryDelay, progressMode = delay, mode })
+	tests := []struct {
+		name      string
+		failures  []string
+		retries   int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"success", nil, 2, 1, false},
+		{"transient then success", []string{"HTTP 502"}, 2, 2, false},
+		{"transient until the last retry", []string{"HTTP 502", "HTTP 503"}, 2, 3, false},
+		{"transient beyond the retries", []string{"HTTP 502", "HTTP 502", "HTTP 502"}, 2, 3, true},
+		{"no retries", []string{"HTTP 502"}, 0, 1, true},
+		{"permanent", []string{"HTTP 401: Bad credentials", "HTTP 502"}, 2, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ghRetries = tt.retries
+			t.Cleanup(func() { ghRetries = defaultRetries })
+			calls := 0
+			_, _, err := withRetries(func() (bytes.Buffer, bytes.Buffer, error) {
+				calls++
+				if calls <= len(tt.failures) {
+					return bytes.Buffer{}, *bytes.NewBufferString(tt.failures[calls-1]), errors.New("exit status 1")
+				}
+				return *bytes.NewBufferString("[]"), bytes.Buffer{}, nil
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("withRetries() made %d calls, want %d", calls, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("withRetries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// approvePRs approves every PR after a single confirmation. A failure on one
+// PR is reported and the batch continues; an error is returned at the end
+// if any approval failed.
+func approvePRs(prs []PullRequest, body string) error {
+	confirmed := false
+	title := fmt.Sprintf("Approve %d pull request(s)?", len(prs))
+	if len(prs) == 1 {
+		title = fmt.Sprintf("Approve PR #%d?", prs[0].Number)
+	}
+	err := newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(title).
+				Description(approvalSummary(prs, body)).
+				Value(&confirmed),
+		),
+	).Run()
+	if err != nil || !confirmed {
+		fmt.Fprintln(os.Stderr, grayStyle.Render("Operation cancelled."))
+		return nil
+	}
+
+	failed := 0
+	for _, pr := range prs {
+		if err := approvePR(pr, body); err != nil {
+			failed++
+			fmt.Printf("%s %s  %s\n", redStyle.Render("✗"), styleID(pr), err)
+			continue
+		}
+		fmt.Printf("%s %s  %s\n", greenStyle.Render("✓"), styleID(pr), pr.Title)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to approve %d of %d PRs", failed, len(prs))
+	}
+	return nil
+}
+
+// approvePR approves pr with an optional comment. The error carries gh's
+// message.
+func approvePR(pr PullRequest, body string) error {
+	var stderrStr string
+	var execErr error
+
+	runWithProgress(fmt.Sprintf("Approving PR #%d...", pr.Number), func() {
+		args := []string{"pr", "review", strconv.Itoa(pr.Number), "--approve"}
+		if body != "" {
+			args = append(args, "--body", body)
+		}
+		_, stderr, err := ghExec(args...)
+		stderrStr = stderr.String()
+		execErr = err
+	})
+
+	if execErr != nil {
+		return errors.New(strings.TrimSpace(stderrStr))
+	}
+	audit.record(pr, "approve")
+	return nil
+}
+
+// approvalSummary lists the PRs about to be approved for the confirmation.
+func approvalSummary(prs []PullRequest, body string) string {
+	lines := make([]string, 0, len(prs)+1)
+	for _, pr := range prs {
+		lines = append(lines, fmt.Sprintf("%s  %s", styleID(pr), pr.Title))
+	}
+	if body != "" {
+		lines = append(lines, grayStyle.Render("Comment: "+body))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var errMultiWithoutAction = errors.New("--multi requires a batch action: --approve")
+
+// reviewColumn shows the PR's review decision. PRs without one, such as
+// most drafts, show a dash.
+var reviewColumn = column{
+	header: "REVIEW",
+	value: func(pr PullRequest) string {
+		switch pr.ReviewDecision {
+		case "APPROVED":
+			return "approved"
+		case "CHANGES_REQUESTED":
+			return "changes"
+		case "REVIEW_REQUIRED":
+			return "required"
+		default:
+			return "-"
+		}
+	},
+	style: func(pr PullRequest) lipgloss.Style {
+		switch pr.ReviewDecision {
+		case "APPROVED":
+			return greenStyle
+		case "CHANGES_REQUESTED":
+			return redStyle
+		case "REVIEW_REQUIRED":
+			return yellowStyle
+		default:
+			return grayStyle
+		}
+	},
+	fields: []string{"reviewDecision"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"approved", greenStyle, "approved"},
+			{"changes", redStyle, "changes requested"},
+			{"required", yellowStyle, "review required"},
+			{"-", grayStyle, "no review decision"},
+		}
+	},
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// sessionFields are the gh pr list fields --session needs. A PR updated
+// after it was handled comes back into the session.
+var sessionFields = []string{"updatedAt"}
+
+// sessionState is what a review session remembers per repository.
+type sessionState struct {
+	Handled map[int]handledPR `json:"handled"`
+}
+
+// handledPR records how and when a PR was handled in a session.
+type handledPR struct {
+	Action string    `json:"action"`
+	At     time.Time `json:"at"`
+}
+
+// sessionPath returns $XDG_STATE_HOME/gh-po/sessions/<owner>/<name>.json,
+// falling back to ~/.local/state.
+func sessionPath(repo string) string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "gh-po", "sessions", filepath.FromSlash(repo)+".json")
+}
+
+func loadSession(path string) (sessionState, error) {
+	state := sessionState{Handled: map[int]handledPR{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read review session: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse review session %s: %w", path, err)
+	}
+	if state.Handled == nil {
+		state.Handled = map[int]handledPR{}
+	}
+	return state, nil
+}
+
+func saveSession(path string, state sessionState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to save review session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save review session: %w", err)
+	}
+	return nil
+}
+
+// runSession walks through the PRs one by one, offering to approve, read,
+// open or skip each. Approved and skipped PRs are remembered per repository
+// so the next session resumes with the rest.
+func runSession(prs []PullRequest, repo, body string) error {
+	if repo == "" {
+		return errors.New("failed to determine the repository for the review session")
+	}
+	path := sessionPath(repo)
+	if path == "" {
+		return errors.New("failed to determine where to keep the review session")
+	}
+	state, err := loadSession(path)
+	if err != nil {
+		return err
+	}
+
+	// Forget PRs that were closed since, and bring back those updated after
+	// they were handled
+	open := map[int]bool{}
+	var queue []PullRequest
+	for _, pr := range prs {
+		open[pr.Number] = true
+		if h, ok := state.Handled[pr.Number]; !ok || pr.UpdatedAt.After(h.At) {
+			queue = append(queue, pr)
+		}
+	}
+	for number := range state.Handled {
+		if !open[number] {
+			delete(state.Handled, number)
+		}
+	}
+
+	done := map[string]int{}
+	remaining := len(queue)
+	defer func() {
+		printSessionSummary(done, remaining, len(prs))
+	}()
+
+	for i, pr := range queue {
+		action, err := sessionStep(pr, i+1, len(queue), body)
+		if err != nil {
+			return err
+		}
+		if action == "quit" {
+			break
+		}
+		state.Handled[pr.Number] = handledPR{Action: action, At: time.Now().UTC()}
+		if err := saveSession(path, state); err != nil {
+			return err
+		}
+		done[action]++
+		remaining--
+	}
+	return saveSession(path, state)
+}
+
+// sessionStep shows the action menu for pr until it is approved, skipped or
+// the session is quit, and returns that action.
+func sessionStep(pr PullRequest, pos, total int, body string) (string, error) {
+	fmt.Printf("\n%s  %s  %s  %s\n", grayStyle.Render(fmt.Sprintf("[%d/%d]", pos, total)), styleID(pr), pr.Title, cyanStyle.Render(pr.HeadRefName))
+
+	for {
+		var action string
+		err := newForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(fmt.Sprintf("PR #%d:", pr.Number)).
+					Options(
+						huh.NewOption("Read description, comments and diff", "read"),
+						huh.NewOption("Open in browser", "open"),
+						huh.NewOption("Approve", "approve"),
+						huh.NewOption("Skip", "skip"),
+						huh.NewOption("Quit session", "quit"),
+					).
+					Value(&action),
+			),
+		).Run()
+		if err != nil {
+			return "quit", nil
+		}
+
+		switch action {
+		case "read":
+			if err := viewPRInTerminal(pr); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		case "open":
+			if err := browsePR(pr, false); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		case "approve":
+			if err := approvePR(pr, body); err != nil {
+				fmt.Printf("%s %s  %s\n", redStyle.Render("✗"), styleID(pr), err)
+				continue
+			}
+			fmt.Printf("%s %s  approved\n", greenStyle.Render("✓"), styleID(pr))
+			return action, nil
+		default:
+			return action, nil
+		}
+	}
+}
+
+func printSessionSummary(done map[string]int, remaining, total int) {
+	var parts []string
+	if n := done["approve"]; n > 0 {
+		parts = append(parts, greenStyle.Render(fmt.Sprintf("%d approved", n)))
+	}
+	if n := done["skip"]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", n))
+	}
+	parts = append(parts, fmt.Sprintf("%d remaining of %d awaiting your review", remaining, total))
+	fmt.Printf("\nReview session: %s\n", strings.Join(parts, ", "))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sizeThresholds are the largest number of changed lines, additions plus
+// deletions, of each size below XL. They are read from the "sizes" section
+// of the config file.
+type sizeThresholds struct {
+	XS int `yaml:"xs"`
+	S  int `yaml:"s"`
+	M  int `yaml:"m"`
+	L  int `yaml:"l"`
+}
+
+var defaultSizeThresholds = sizeThresholds{XS: 10, S: 50, M: 250, L: 1000}
+
+// sizes are the badges of the SIZE column from smallest to largest, also
+// the accepted --max-size values.
+var sizes = []string{"XS", "S", "M", "L", "XL"}
+
+// prSizes holds the thresholds the SIZE column and --max-size use.
+var prSizes = defaultSizeThresholds
+
+// sizeColumn shows the PR size as a T-shirt size badge.
+var sizeColumn = column{
+	header: "SIZE",
+	value:  prSize,
+	style: func(pr PullRequest) lipgloss.Style {
+		switch prSize(pr) {
+		case "XS", "S":
+			return greenStyle
+		case "M":
+			return yellowStyle
+		default:
+			return redStyle
+		}
+	},
+	fields: diffStatFields,
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"S", greenStyle, "up to " + strconv.Itoa(prSizes.S) + " changed lines"},
+			{"M", yellowStyle, "up to " + strconv.Itoa(prSizes.M) + " changed lines"},
+			{"L", redStyle, "more than " + strconv.Itoa(prSizes.M) + " changed lines"},
+		}
+	},
+}
+
+// prSize returns the size badge of pr from its additions and deletions.
+func prSize(pr PullRequest) string {
+	changed := pr.Additions + pr.Deletions
+	for i, limit := range []int{prSizes.XS, prSizes.S, prSizes.M, prSizes.L} {
+		if changed <= limit {
+			return sizes[i]
+		}
+	}
+	return sizes[len(sizes)-1]
+}
+
+// atMostSize drops the PRs larger than size.
+func atMostSize(prs []PullRequest, size string) []PullRequest {
+	max := slices.Index(sizes, size)
+	return slices.DeleteFunc(prs, func(pr PullRequest) bool {
+		return slices.Index(sizes, prSize(pr)) > max
+	})
+}
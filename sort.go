@@ -0,0 +1,103 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sortOrders are the accepted --sort values. Without --sort PRs keep gh's
+// order, newest first.
+var sortOrders = []string{"created", "updated", "number", "title", "urgency", "waiting"}
+
+// liveSortOrders are the orders s cycles through in the picker, "" being
+// gh's order. They only need fields that are always fetched.
+var liveSortOrders = []string{"", "created", "updated", "number", "title"}
+
+// sortColumns names the column whose header is highlighted for a sort order.
+var sortColumns = map[string]string{
+	"created": "created",
+	"number":  "id",
+	"title":   "title",
+	"updated": "updated",
+	"urgency": "urgency",
+	"waiting": "waiting",
+}
+
+// activeSort is the order the PRs are listed in, and reverseSort whether it
+// is reversed (--sort, --reverse). The picker changes activeSort with s.
+var (
+	activeSort  string
+	reverseSort bool
+)
+
+// sortTimes are the orders that need a time, and the time each needs.
+// Urgency and waiting are both computed from the creation time.
+var sortTimes = map[string]func(PullRequest) time.Time{
+	"created": func(pr PullRequest) time.Time { return pr.CreatedAt },
+	"updated": func(pr PullRequest) time.Time { return pr.UpdatedAt },
+	"urgency": func(pr PullRequest) time.Time { return pr.CreatedAt },
+	"waiting": func(pr PullRequest) time.Time { return pr.CreatedAt },
+}
+
+// sortPRs orders prs by one of sortOrders, reversed with reverse. Ties keep
+// their relative order either way. Urgency must have been scored. PRs
+// without the time an order needs, like updatedAt under --json, go last
+// reversed or not.
+func sortPRs(prs []PullRequest, order string, reverse bool) {
+	if at, ok := sortTimes[order]; ok {
+		prs = prs[:partitionUndated(prs, at)]
+	}
+	if reverse && order == "" {
+		slices.Reverse(prs)
+		return
+	}
+	// Reversing before and after a stable sort flips the order but not the
+	// ties
+	if reverse {
+		slices.Reverse(prs)
+		defer slices.Reverse(prs)
+	}
+	switch order {
+	case "created":
+		sort.SliceStable(prs, func(i, j int) bool { return prs[i].CreatedAt.After(prs[j].CreatedAt) })
+	case "updated":
+		sort.SliceStable(prs, func(i, j int) bool { return prs[i].UpdatedAt.After(prs[j].UpdatedAt) })
+	case "number":
+		sort.SliceStable(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+	case "title":
+		sort.SliceStable(prs, func(i, j int) bool { return strings.ToLower(prs[i].Title) < strings.ToLower(prs[j].Title) })
+	case "urgency":
+		sortByUrgency(prs)
+	case "waiting":
+		sortByWaiting(prs)
+	}
+}
+
+// partitionUndated stably moves the PRs whose at time is zero to the end of
+// prs and returns how many have one.
+func partitionUndated(prs []PullRequest, at func(PullRequest) time.Time) int {
+	var dated, undated []PullRequest
+	for _, pr := range prs {
+		if at(pr).IsZero() {
+			undated = append(undated, pr)
+		} else {
+			dated = append(dated, pr)
+		}
+	}
+	copy(prs, dated)
+	copy(prs[len(dated):], undated)
+	return len(dated)
+}
+
+// nextLiveSort is the order after order in liveSortOrders, wrapping around.
+// Orders that can't be cycled to start over at the first.
+func nextLiveSort(order string) string {
+	i := slices.Index(liveSortOrders, order)
+	return liveSortOrders[(i+1)%len(liveSortOrders)]
+}
+
+func validSortOrder(order string) bool {
+	return slices.Contains(sortOrders, order)
+}
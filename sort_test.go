@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortPRs(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1, Title: "b", CreatedAt: day},
+		{Number: 2, Title: "A"},
+		{Number: 3, Title: "c", CreatedAt: day.Add(time.Hour)},
+		{Number: 4, Title: "a", CreatedAt: day},
+	}
+	tests := []struct {
+		order   string
+		reverse bool
+		want    []int
+	}{
+		{"", false, []int{1, 2, 3, 4}},
+		{"", true, []int{4, 3, 2, 1}},
+		{"created", false, []int{3, 1, 4, 2}},
+		{"created", true, []int{1, 4, 3, 2}},
+		{"number", true, []int{4, 3, 2, 1}},
+		{"title", false, []int{2, 4, 1, 3}},
+	}
+	for _, tt := range tests {
+		sorted := append([]PullRequest{}, prs...)
+		sortPRs(sorted, tt.order, tt.reverse)
+		got := make([]int, len(sorted))
+		for i, pr := range sorted {
+			got[i] = pr.Number
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sortPRs(%q, reverse=%v) = %v, want %v", tt.order, tt.reverse, got, tt.want)
+		}
+	}
+}
+
+func TestSortPRsUndatedLast(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1},
+		{Number: 2, CreatedAt: day, UpdatedAt: day.Add(time.Hour)},
+		{Number: 3},
+		{Number: 4, CreatedAt: day.Add(time.Hour), UpdatedAt: day},
+	}
+	tests := []struct {
+		order   string
+		reverse bool
+		want    []int
+	}{
+		{"created", false, []int{4, 2, 1, 3}},
+		{"created", true, []int{2, 4, 1, 3}},
+		{"updated", false, []int{2, 4, 1, 3}},
+		{"updated", true, []int{4, 2, 1, 3}},
+		{"waiting", false, []int{2, 4, 1, 3}},
+		{"waiting", true, []int{4, 2, 1, 3}},
+	}
+	for _, tt := range tests {
+		sorted := append([]PullRequest{}, prs...)
+		sortPRs(sorted, tt.order, tt.reverse)
+		got := make([]int, len(sorted))
+		for i, pr := range sorted {
+			got[i] = pr.Number
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sortPRs(%q, reverse=%v) = %v, want %v", tt.order, tt.reverse, got, tt.want)
+		}
+	}
+}
+
+func TestSortPRsByUrgencyUndatedLast(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1, urgency: 5},
+		{Number: 2, urgency: 1, CreatedAt: day},
+		{Number: 3},
+		{Number: 4, urgency: 3, CreatedAt: day},
+	}
+	tests := []struct {
+		reverse bool
+		want    []int
+	}{
+		{false, []int{4, 2, 1, 3}},
+		{true, []int{2, 4, 1, 3}},
+	}
+	for _, tt := range tests {
+		sorted := append([]PullRequest{}, prs...)
+		sortPRs(sorted, "urgency", tt.reverse)
+		got := make([]int, len(sorted))
+		for i, pr := range sorted {
+			got[i] = pr.Number
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sortPRs(\"urgency\", reverse=%v) = %v, want %v", tt.reverse, got, tt.want)
+		}
+	}
+}
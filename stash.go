@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// stashMarker starts the message of stashes made by --stash. It is followed
+// by the branch the changes belong to, so --stash-pop can refuse to restore
+// them elsewhere.
+const stashMarker = "gh-po: "
+
+// stashBeforeCheckout stashes uncommitted changes, untracked files included,
+// before pr is checked out. A clean tree is left alone.
+func stashBeforeCheckout(pr PullRequest) error {
+	dirty, err := workingTreeDirty()
+	if err != nil || !dirty {
+		return err
+	}
+
+	branch, err := currentBranch()
+	if err != nil {
+		return err
+	}
+	message := stashMarker + branch + " before #" + strconv.Itoa(pr.Number)
+	stdout, stderr, err := runGit("stash", "push", "--include-untracked", "-m", message)
+	fmt.Print(stdout)
+	if err != nil {
+		return fmt.Errorf("failed to stash changes: %s", strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// workingTreeDirty reports whether there are uncommitted changes or
+// untracked files.
+func workingTreeDirty() (bool, error) {
+	status, stderr, err := runGit("status", "--porcelain")
+	if err != nil {
+		return false, fmt.Errorf("failed to check the working tree: %s", strings.TrimSpace(stderr))
+	}
+	return strings.TrimSpace(status) != "", nil
+}
+
+// confirmDirtyCheckout asks whether to check out pr although the working
+// tree has uncommitted changes. A clean tree needs no confirmation.
+func confirmDirtyCheckout(pr PullRequest) (bool, error) {
+	dirty, err := workingTreeDirty()
+	if err != nil || !dirty {
+		return err == nil, err
+	}
+	confirmed := false
+	err = newForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Check out PR #%d with uncommitted changes?", pr.Number)).
+				Description("Switching branches may carry them over to the PR's branch or fail on them.\nUse --stash to put them away first.").
+				Value(&confirmed),
+		),
+	).Run()
+	return err == nil && confirmed, nil
+}
+
+// popStash restores the newest stash --stash made on the current branch.
+// Stashes from other branches are never applied.
+func popStash() error {
+	branch, err := currentBranch()
+	if err != nil {
+		return err
+	}
+	list, stderr, err := runGit("stash", "list", "--format=%gd %s")
+	if err != nil {
+		return fmt.Errorf("failed to list stashes: %s", strings.TrimSpace(stderr))
+	}
+
+	var others []string
+	for _, line := range strings.Split(strings.TrimSpace(list), "\n") {
+		ref, subject, _ := strings.Cut(line, " ")
+		_, rest, ok := strings.Cut(subject, stashMarker)
+		if !ok {
+			continue
+		}
+		stashBranch, _, _ := strings.Cut(rest, " before #")
+		if stashBranch != branch {
+			others = append(others, stashBranch)
+			continue
+		}
+
+		stdout, stderr, err := runGit("stash", "pop", ref)
+		fmt.Print(stdout)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %s", ref, strings.TrimSpace(stderr))
+		}
+		return nil
+	}
+
+	if len(others) > 0 {
+		return fmt.Errorf("no stash from gh po for %s; the newest one belongs to %s, switch to it first", branch, others[0])
+	}
+	return errors.New("no stash from gh po to restore")
+}
+
+// currentBranch returns the checked out branch. A detached HEAD is an error.
+func currentBranch() (string, error) {
+	stdout, stderr, err := runGit("branch", "--show-current")
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the current branch: %s", strings.TrimSpace(stderr))
+	}
+	branch := strings.TrimSpace(stdout)
+	if branch == "" {
+		return "", errors.New("HEAD is detached, check out a branch first")
+	}
+	return branch, nil
+}
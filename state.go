@@ -0,0 +1,49 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prStates are the accepted --state values, as understood by gh pr list.
+var prStates = []string{"open", "closed", "merged", "all"}
+
+// stateAdjective qualifies "pull requests" in messages for state, e.g.
+// "closed " or "" for all states.
+func stateAdjective(state string) string {
+	if state == "all" {
+		return ""
+	}
+	return state + " "
+}
+
+func validState(state string) bool {
+	return slices.Contains(prStates, state)
+}
+
+// stateColumn tells open, merged and closed PRs apart. It is shown when
+// --state lists more than open PRs.
+var stateColumn = column{
+	name:   "state",
+	header: "STATE",
+	value:  func(pr PullRequest) string { return pr.State },
+	style: func(pr PullRequest) lipgloss.Style {
+		switch pr.State {
+		case "MERGED":
+			return magentaStyle
+		case "CLOSED":
+			return redStyle
+		default:
+			return greenStyle
+		}
+	},
+	fields: []string{"state"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"OPEN", greenStyle, "open"},
+			{"MERGED", magentaStyle, "merged"},
+			{"CLOSED", redStyle, "closed without merging"},
+		}
+	},
+}
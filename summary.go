@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// summaryLimit is how many of the most recent PRs --summary looks at, in
+// any state.
+const summaryLimit = 200
+
+// summaryFields are the gh pr list fields --summary needs.
+var summaryFields = []string{"state", "reviewDecision"}
+
+// prSummary is the --summary report. States count every sampled PR, the
+// other counts only the open ones.
+type prSummary struct {
+	Repo            string         `json:"repo"`
+	Sampled         int            `json:"sampled"`
+	States          map[string]int `json:"states"`
+	Ready           int            `json:"ready"`
+	Draft           int            `json:"draft"`
+	ReviewDecisions map[string]int `json:"reviewDecisions"`
+	OldestOpen      *oldestPR      `json:"oldestOpen"`
+}
+
+type oldestPR struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// summarize counts prs by state, draft and review decision. PRs without a
+// review decision count as "NONE".
+func summarize(repo string, prs []PullRequest) prSummary {
+	s := prSummary{
+		Repo:            repo,
+		Sampled:         len(prs),
+		States:          map[string]int{},
+		ReviewDecisions: map[string]int{},
+	}
+	for _, pr := range prs {
+		s.States[pr.State]++
+		if pr.State != "OPEN" {
+			continue
+		}
+		if pr.IsDraft {
+			s.Draft++
+		} else {
+			s.Ready++
+		}
+		decision := pr.ReviewDecision
+		if decision == "" {
+			decision = "NONE"
+		}
+		s.ReviewDecisions[decision]++
+		if s.OldestOpen == nil || pr.CreatedAt.Before(s.OldestOpen.CreatedAt) {
+			s.OldestOpen = &oldestPR{Number: pr.Number, Title: pr.Title, CreatedAt: pr.CreatedAt}
+		}
+	}
+	return s
+}
+
+// printSummary writes s for humans, e.g.
+//
+//	owner/name · last 200 PRs
+//	State     12 open, 150 merged, 38 closed
+//	Open      9 ready, 3 draft
+//	Review    4 approved, 3 review required, 2 changes requested, 3 none
+//	Oldest    #12 about 3 months ago  Fix the thing
+func printSummary(w io.Writer, s prSummary) {
+	header := fmt.Sprintf("last %d PRs", s.Sampled)
+	if s.Repo != "" {
+		header = s.Repo + " · " + header
+	}
+	fmt.Fprintln(w, grayStyle.Render(header))
+	fmt.Fprintf(w, "State     %s\n", countList(s.States, []string{"OPEN", "MERGED", "CLOSED"}))
+	fmt.Fprintf(w, "Open      %d ready, %d draft\n", s.Ready, s.Draft)
+	fmt.Fprintf(w, "Review    %s\n", countList(s.ReviewDecisions, []string{"APPROVED", "REVIEW_REQUIRED", "CHANGES_REQUESTED", "NONE"}))
+	if s.OldestOpen == nil {
+		fmt.Fprintln(w, "Oldest    -")
+		return
+	}
+	fmt.Fprintf(w, "Oldest    %s %s  %s\n",
+		greenStyle.Render("#"+strconv.Itoa(s.OldestOpen.Number)), displayTime(s.OldestOpen.CreatedAt), s.OldestOpen.Title)
+}
+
+// countList renders counts as "4 approved, 2 changes requested", the keys
+// in order first and any others after them.
+func countList(counts map[string]int, order []string) string {
+	for _, key := range sortedKeys(counts) {
+		if !slices.Contains(order, key) {
+			order = append(order, key)
+		}
+	}
+	var parts []string
+	for _, key := range order {
+		if counts[key] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[key], strings.ToLower(strings.ReplaceAll(key, "_", " "))))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mfyuu/gh-po/internal/prlist"
+)
+
+// column describes one column of the PR table.
+type column struct {
+	// name identifies the column in flags such as --columns
+	name   string
+	header string
+	// maxWidth caps the column width; longer values are truncated. 0 means
+	// the column grows to fit its widest value.
+	maxWidth int
+	value    func(pr PullRequest) string
+	// style colors the padded cell. nil renders the cell unstyled.
+	style func(pr PullRequest) lipgloss.Style
+	// render draws the cell itself instead of value and style, e.g. in
+	// several colors. It must fill width and apply bg if it isn't nil.
+	render func(pr PullRequest, width int, bg lipgloss.TerminalColor) string
+	// fields lists the gh pr list JSON fields the column needs beyond baseFields
+	fields []string
+	// legend explains the column's colors and symbols for --legend
+	legend func() []legendEntry
+}
+
+var (
+	idColumn = column{
+		name:   "id",
+		header: "ID",
+		value:  func(pr PullRequest) string { return fmt.Sprintf("#%d", pr.Number) },
+		style:  idStyle,
+		legend: func() []legendEntry {
+			return []legendEntry{
+				{"#123", readyStyle, "ready for review"},
+				{"#123", draftStyle, "draft"},
+			}
+		},
+	}
+	titleColumn = column{
+		name:     "title",
+		header:   "TITLE",
+		maxWidth: 100,
+		value:    func(pr PullRequest) string { return pr.Title },
+	}
+	branchColumn = column{
+		name:     "branch",
+		header:   "BRANCH",
+		maxWidth: 30,
+		value:    func(pr PullRequest) string { return pr.HeadRefName },
+		style:    func(PullRequest) lipgloss.Style { return cyanStyle },
+		legend: func() []legendEntry {
+			return []legendEntry{{"branch", cyanStyle, "head branch"}}
+		},
+	}
+	createdColumn = column{
+		name:   "created",
+		header: "CREATED AT",
+		value:  func(pr PullRequest) string { return displayTime(pr.CreatedAt) },
+		style:  createdStyle,
+		legend: func() []legendEntry {
+			if slaThreshold == 0 {
+				return staleLegend()
+			}
+			return []legendEntry{
+				{"3 days ago", yellowStyle, "nearing the --sla age"},
+				{"9 days ago", redStyle, "open longer than the --sla age"},
+			}
+		},
+	}
+)
+
+// tableWidth is the width rows are fitted to by shrinking the columns in
+// truncateOrder. 0 disables fitting.
+var tableWidth int
+
+// truncateOrder names the columns shrunk first when rows are wider than
+// tableWidth (--truncate-order).
+var truncateOrder = []string{"title", "branch"}
+
+// dropOrder names the columns hidden, one after the other, when rows don't
+// fit into tableWidth even with every column shrunk. ID and TITLE are never
+// hidden.
+var dropOrder = []string{"created", "updated", "branch"}
+
+// listSummary is shown above the column header, e.g. to tell how many PRs
+// the active filters hide.
+var listSummary string
+
+// tableBorders draws box-drawing borders around the cells (--borders).
+var tableBorders bool
+
+// zebraRows enables alternating row backgrounds (--zebra).
+var zebraRows bool
+
+// zebraBackground is the subtle background of every other row with --zebra.
+var zebraBackground = lipgloss.AdaptiveColor{Light: "254", Dark: "236"}
+
+// defaultColumns are always shown, in this order.
+var defaultColumns = []column{idColumn, titleColumn, branchColumn, createdColumn}
+
+// optionalColumns can be appended to the defaults with --columns.
+var optionalColumns = map[string]column{
+	"assignees": assigneesColumn,
+	"author":    authorColumn,
+	"base":      baseColumn,
+	"behind":    behindColumn,
+	"checks":    checksColumn,
+	"labels":    labelsColumn,
+	"ci-time":   ciTimeColumn,
+	"review":    reviewColumn,
+	"sha":       shaColumn,
+	"size":      sizeColumn,
+	"flow":      flowColumn,
+	"urgency":   urgencyColumn,
+	"waiting":   waitingColumn,
+}
+
+// wideColumns are the optional columns --wide adds, besides UPDATED.
+var wideColumns = []string{"author", "base", "sha"}
+
+// optionalColumnNames returns the accepted --columns values in sorted order.
+func optionalColumnNames() []string {
+	return sortedKeys(optionalColumns)
+}
+
+// tableColumns returns the default columns followed by the named optional
+// columns in the order given. With noTruncate the width caps are dropped so
+// every value is shown in full.
+func tableColumns(names []string, noTruncate bool) []column {
+	cols := append([]column{}, defaultColumns...)
+	for _, name := range names {
+		col := optionalColumns[name]
+		col.name = name
+		cols = append(cols, col)
+	}
+	if noTruncate {
+		for i := range cols {
+			cols[i].maxWidth = 0
+		}
+	}
+	return cols
+}
+
+// columnFields returns the extra gh pr list fields needed by cols.
+func columnFields(cols []column) []string {
+	var fields []string
+	for _, col := range cols {
+		fields = append(fields, col.fields...)
+	}
+	return fields
+}
+
+// layout is the table layout of the current flags and theme.
+func layout() prlist.Table {
+	return prlist.Table{
+		Width:             tableWidth,
+		TruncateOrder:     truncateOrder,
+		DropOrder:         dropOrder,
+		Borders:           tableBorders,
+		HeaderStyle:       underlineStyle,
+		SortedHeaderStyle: sortedHeaderStyle,
+		BorderStyle:       grayStyle,
+	}
+}
+
+// layoutColumns returns cols as laid out by prlist.
+func layoutColumns(cols []column) []prlist.Column[PullRequest] {
+	layoutCols := make([]prlist.Column[PullRequest], len(cols))
+	for i, col := range cols {
+		layoutCols[i] = prlist.Column[PullRequest]{
+			Name: col.name, Header: col.header, MaxWidth: col.maxWidth,
+			Value: col.value, Style: col.style, Render: col.render,
+		}
+	}
+	return layoutCols
+}
+
+// columnWidths calculates the display width of each column, fitted into
+// tableWidth. A width of 0 hides the column.
+func columnWidths(prs []PullRequest, cols []column) []int {
+	return prlist.Widths(layout(), prs, layoutColumns(cols))
+}
+
+// fitWidths shrinks the columns named in order until a row fits into width,
+// hiding the columns in dropOrder if that is not enough.
+func fitWidths(widths []int, cols []column, width int, order []string) {
+	t := layout()
+	t.Width, t.TruncateOrder = width, order
+	prlist.Fit(t, widths, layoutColumns(cols))
+}
+
+// columnNames returns the names of cols.
+func columnNames(cols []column) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.name
+	}
+	return names
+}
+
+// buildOptions renders each PR as an aligned option label and returns them
+// together with the matching column header. Option values are indexes into prs.
+func buildOptions(prs []PullRequest, cols []column) ([]huh.Option[int], string) {
+	widths := columnWidths(prs, cols)
+
+	options := make([]huh.Option[int], len(prs))
+	for i, pr := range prs {
+		label := formatPR(pr, cols, widths)
+		if zebraRows && i%2 == 1 {
+			label = formatRow(pr, cols, widths, zebraBackground)
+		}
+		options[i] = huh.NewOption(label, i)
+	}
+
+	return options, buildHeader(cols, widths)
+}
+
+// buildHeader renders the column headers over widths, below listSummary.
+func buildHeader(cols []column, widths []int) string {
+	header := prlist.Header(layout(), layoutColumns(cols), widths, sortColumns[activeSort])
+	if listSummary != "" {
+		header = "  " + grayStyle.Render(listSummary) + "\n" + header
+	}
+	return header
+}
+
+func idStyle(pr PullRequest) lipgloss.Style {
+	if pr.IsDraft {
+		return draftStyle
+	}
+	return readyStyle
+}
+
+func styleID(pr PullRequest) string {
+	return idStyle(pr).Render(fmt.Sprintf("#%d", pr.Number))
+}
+
+func formatPR(pr PullRequest, cols []column, widths []int) string {
+	return formatRow(pr, cols, widths, nil)
+}
+
+// formatRow renders one table row, on the background bg if it isn't nil.
+func formatRow(pr PullRequest, cols []column, widths []int, bg lipgloss.TerminalColor) string {
+	return prlist.FormatRow(layout(), pr, layoutColumns(cols), widths, bg)
+}
+
+// flowColumn shows the head and base branch of a PR, fork PRs in magenta.
+var flowColumn = column{
+	header:   "FLOW",
+	maxWidth: 60,
+	value:    prFlow,
+	style: func(pr PullRequest) lipgloss.Style {
+		if pr.IsCrossRepository {
+			return magentaStyle
+		}
+		return grayStyle
+	},
+	fields: []string{"headRepositoryOwner", "baseRefName", "isCrossRepository"},
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"user:branch → owner:main", magentaStyle, "PR from a fork"},
+			{"branch → main", grayStyle, "PR from the same repository"},
+		}
+	},
+}
+
+// prFlow describes where the PR merges from and to. Fork PRs are qualified
+// with the owners, e.g. "contributor:fix → owner:main"; same-repo PRs show
+// the bare branch names.
+func prFlow(pr PullRequest) string {
+	if !pr.IsCrossRepository {
+		return pr.HeadRefName + " → " + pr.BaseRefName
+	}
+	base := pr.BaseRefName
+	if pr.baseOwner != "" {
+		base = pr.baseOwner + ":" + base
+	}
+	return headLabel(pr) + " → " + base
+}
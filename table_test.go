@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestMain(m *testing.M) {
+	// Compare rendered rows as plain text
+	lipgloss.SetColorProfile(termenv.Ascii)
+	os.Exit(m.Run())
+}
+
+func TestDefaultColumns(t *testing.T) {
+	pr := PullRequest{Number: 12, Title: "Fix the login form", HeadRefName: "fix-login"}
+	tests := []struct {
+		col      column
+		header   string
+		maxWidth int
+		value    string
+	}{
+		{idColumn, "ID", 0, "#12"},
+		{titleColumn, "TITLE", 100, "Fix the login form"},
+		{branchColumn, "BRANCH", 30, "fix-login"},
+		{createdColumn, "CREATED AT", 0, "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.col.name, func(t *testing.T) {
+			if tt.col.header != tt.header || tt.col.maxWidth != tt.maxWidth {
+				t.Errorf("column = %q capped at %d, want %q capped at %d", tt.col.header, tt.col.maxWidth, tt.header, tt.maxWidth)
+			}
+			if got := tt.col.value(pr); got != tt.value {
+				t.Errorf("value() = %q, want %q", got, tt.value)
+			}
+		})
+	}
+	var names []string
+	for _, col := range defaultColumns {
+		names = append(names, col.name)
+	}
+	if want := []string{"id", "title", "branch", "created"}; !slices.Equal(names, want) {
+		t.Errorf("defaultColumns = %v, want %v", names, want)
+	}
+}
+
+func TestIDStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft bool
+		want  lipgloss.TerminalColor
+	}{
+		{"ready for review", false, lipgloss.Color("2")},
+		{"draft", true, lipgloss.Color("3")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idStyle(PullRequest{Number: 1, IsDraft: tt.draft}).GetForeground()
+			if got != tt.want {
+				t.Errorf("idStyle().GetForeground() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildOptions(t *testing.T) {
+	for _, n := range []int{0, 1, 5} {
+		prs := make([]PullRequest, n)
+		for i := range prs {
+			prs[i] = PullRequest{Number: i + 1, Title: "PR"}
+		}
+		options, header := buildOptions(prs, defaultColumns)
+		if len(options) != n {
+			t.Errorf("buildOptions() with %d PRs returned %d options", n, len(options))
+		}
+		if !strings.HasPrefix(header, "  ID") {
+			t.Errorf("buildOptions() header = %q", header)
+		}
+	}
+}
+
+func TestNoTruncateKeepsFullValues(t *testing.T) {
+	pr := PullRequest{Number: 1, Title: strings.Repeat("A very long title ", 20), HeadRefName: strings.Repeat("b", 40)}
+	for _, noTruncate := range []bool{false, true} {
+		cols := tableColumns(nil, noTruncate)
+		row := formatPR(pr, cols, columnWidths([]PullRequest{pr}, cols))
+		full := strings.Contains(row, pr.Title) && strings.Contains(row, pr.HeadRefName)
+		if full != noTruncate {
+			t.Errorf("formatPR() with noTruncate=%v = %q, full values shown = %v", noTruncate, row, full)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// theme maps every color gh po draws with, the forms' included (--theme).
+type theme struct {
+	red, green, yellow, magenta, cyan, gray, text lipgloss.TerminalColor
+	// draft and ready color the PR number
+	draft, ready lipgloss.TerminalColor
+	form         func() *huh.Theme
+	// markdown is the glamour style PR bodies are rendered in. "" picks
+	// the dark or light one for the terminal's background.
+	markdown string
+}
+
+// themes are the accepted --theme values.
+var themes = map[string]theme{
+	"default": {
+		red: lipgloss.Color("1"), green: lipgloss.Color("2"), yellow: lipgloss.Color("3"),
+		magenta: lipgloss.Color("5"), cyan: lipgloss.Color("6"), gray: lipgloss.Color("8"),
+		text: lipgloss.Color("7"), draft: lipgloss.Color("3"), ready: lipgloss.Color("2"),
+		form: huh.ThemeCharm,
+	},
+	"dracula": {
+		red: lipgloss.Color("#ff5555"), green: lipgloss.Color("#50fa7b"), yellow: lipgloss.Color("#f1fa8c"),
+		magenta: lipgloss.Color("#ff79c6"), cyan: lipgloss.Color("#8be9fd"), gray: lipgloss.Color("#6272a4"),
+		text: lipgloss.Color("#f8f8f2"), draft: lipgloss.Color("#ffb86c"), ready: lipgloss.Color("#50fa7b"),
+		form: huh.ThemeDracula, markdown: styles.DraculaStyle,
+	},
+	"base16": {
+		red: lipgloss.Color("9"), green: lipgloss.Color("10"), yellow: lipgloss.Color("11"),
+		magenta: lipgloss.Color("13"), cyan: lipgloss.Color("14"), gray: lipgloss.Color("8"),
+		text: lipgloss.Color("15"), draft: lipgloss.Color("11"), ready: lipgloss.Color("10"),
+		form: huh.ThemeBase16,
+	},
+	"mono": {
+		red: lipgloss.NoColor{}, green: lipgloss.NoColor{}, yellow: lipgloss.NoColor{},
+		magenta: lipgloss.NoColor{}, cyan: lipgloss.NoColor{}, gray: lipgloss.NoColor{},
+		text: lipgloss.NoColor{}, draft: lipgloss.NoColor{}, ready: lipgloss.NoColor{},
+		form: huh.ThemeBase, markdown: styles.NoTTYStyle,
+	},
+}
+
+// defaultTheme is the --theme used when none is given.
+const defaultTheme = "default"
+
+// activeTheme is the theme the styles below were built from.
+var activeTheme theme
+
+var (
+	redStyle       lipgloss.Style
+	greenStyle     lipgloss.Style
+	yellowStyle    lipgloss.Style
+	magentaStyle   lipgloss.Style
+	cyanStyle      lipgloss.Style
+	grayStyle      lipgloss.Style
+	underlineStyle lipgloss.Style
+	draftStyle     lipgloss.Style
+	readyStyle     lipgloss.Style
+	// sortedHeaderStyle highlights the header of the column the PRs are sorted by.
+	sortedHeaderStyle lipgloss.Style
+)
+
+func init() {
+	applyTheme(themes[defaultTheme])
+}
+
+// themeOverrides are the GH_PO_COLOR_* variables and the theme color each
+// one replaces.
+var themeOverrides = map[string]func(*theme) *lipgloss.TerminalColor{
+	"GH_PO_COLOR_RED":     func(t *theme) *lipgloss.TerminalColor { return &t.red },
+	"GH_PO_COLOR_GREEN":   func(t *theme) *lipgloss.TerminalColor { return &t.green },
+	"GH_PO_COLOR_YELLOW":  func(t *theme) *lipgloss.TerminalColor { return &t.yellow },
+	"GH_PO_COLOR_MAGENTA": func(t *theme) *lipgloss.TerminalColor { return &t.magenta },
+	"GH_PO_COLOR_CYAN":    func(t *theme) *lipgloss.TerminalColor { return &t.cyan },
+	"GH_PO_COLOR_GRAY":    func(t *theme) *lipgloss.TerminalColor { return &t.gray },
+	"GH_PO_COLOR_TEXT":    func(t *theme) *lipgloss.TerminalColor { return &t.text },
+	"GH_PO_COLOR_DRAFT":   func(t *theme) *lipgloss.TerminalColor { return &t.draft },
+	"GH_PO_COLOR_READY":   func(t *theme) *lipgloss.TerminalColor { return &t.ready },
+}
+
+// withOverrides returns t with the colors set through GH_PO_COLOR_*
+// variables, which take an ANSI number like 4 or a hex color like #0969da.
+func (t theme) withOverrides() theme {
+	for name, field := range themeOverrides {
+		if v := os.Getenv(name); v != "" {
+			*field(&t) = lipgloss.Color(v)
+		}
+	}
+	return t
+}
+
+// applyTheme rebuilds every style from t.
+func applyTheme(t theme) {
+	activeTheme = t
+	redStyle = lipgloss.NewStyle().Foreground(t.red)
+	greenStyle = lipgloss.NewStyle().Foreground(t.green)
+	yellowStyle = lipgloss.NewStyle().Foreground(t.yellow)
+	magentaStyle = lipgloss.NewStyle().Foreground(t.magenta)
+	cyanStyle = lipgloss.NewStyle().Foreground(t.cyan)
+	grayStyle = lipgloss.NewStyle().Foreground(t.gray)
+	underlineStyle = lipgloss.NewStyle().Underline(true).Foreground(t.text)
+	draftStyle = lipgloss.NewStyle().Foreground(t.draft)
+	readyStyle = lipgloss.NewStyle().Foreground(t.ready)
+	sortedHeaderStyle = underlineStyle.Bold(true).Foreground(t.cyan)
+}
+
+// newForm is huh.NewForm in the colors of the active theme.
+func newForm(groups ...*huh.Group) *huh.Form {
+	return huh.NewForm(groups...).WithTheme(activeTheme.form())
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestThemeWithOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		wantDraft lipgloss.TerminalColor
+		wantRed   lipgloss.TerminalColor
+	}{
+		{"no overrides", nil, lipgloss.Color("3"), lipgloss.Color("1")},
+		{"draft color", map[string]string{"GH_PO_COLOR_DRAFT": "#8250df"}, lipgloss.Color("#8250df"), lipgloss.Color("1")},
+		{"empty is ignored", map[string]string{"GH_PO_COLOR_RED": ""}, lipgloss.Color("3"), lipgloss.Color("1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			got := themes[defaultTheme].withOverrides()
+			if got.draft != tt.wantDraft {
+				t.Errorf("draft = %v, want %v", got.draft, tt.wantDraft)
+			}
+			if got.red != tt.wantRed {
+				t.Errorf("red = %v, want %v", got.red, tt.wantRed)
+			}
+		})
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { applyTheme(themes[defaultTheme]) })
+	applyTheme(themes["mono"])
+	if got := idStyle(PullRequest{IsDraft: true}).GetForeground(); got != (lipgloss.NoColor{}) {
+		t.Errorf("draft ID color with mono = %v, want none", got)
+	}
+	if activeTheme.form == nil {
+		t.Error("mono has no form theme")
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// prDetails is the conversation shown by --tui-view.
+type prDetails struct {
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Comments []struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"comments"`
+}
+
+const (
+	tabConversation = iota
+	tabDiff
+)
+
+type detailsMsg struct {
+	details prDetails
+	err     error
+}
+
+type diffMsg struct {
+	diff string
+	err  error
+}
+
+// prViewer shows a PR's conversation and diff in a scrollable viewport.
+// Both are fetched in the background; the diff only once its tab is opened.
+type prViewer struct {
+	pr       PullRequest
+	viewport viewport.Model
+	ready    bool
+	tab      int
+
+	details     *prDetails
+	detailsErr  error
+	diff        *string
+	diffLoading bool
+	diffErr     error
+}
+
+// viewPRInTerminal opens the full-screen viewer for pr.
+func viewPRInTerminal(pr PullRequest) error {
+	_, err := tea.NewProgram(&prViewer{pr: pr}, tea.WithAltScreen(), tea.WithOutput(uiOutput)).Run()
+	return err
+}
+
+func fetchDetails(number int) tea.Cmd {
+	return func() tea.Msg {
+		stdout, stderr, err := ghExec("pr", "view", strconv.Itoa(number), "--json", "title,body,url,author,comments")
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("failed to fetch PR #%d: %s", number, strings.TrimSpace(stderr.String()))}
+		}
+		var d prDetails
+		if err := json.Unmarshal(stdout.Bytes(), &d); err != nil {
+			return detailsMsg{err: fmt.Errorf("failed to parse PR #%d: %w", number, err)}
+		}
+		return detailsMsg{details: d}
+	}
+}
+
+func fetchDiff(number int) tea.Cmd {
+	return func() tea.Msg {
+		stdout, stderr, err := ghExec("pr", "diff", strconv.Itoa(number), "--color", "never")
+		if err != nil {
+			return diffMsg{err: fmt.Errorf("failed to fetch diff for PR #%d: %s", number, strings.TrimSpace(stderr.String()))}
+		}
+		return diffMsg{diff: stdout.String()}
+	}
+}
+
+func (v *prViewer) Init() tea.Cmd {
+	return fetchDetails(v.pr.Number)
+}
+
+func (v *prViewer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return v, tea.Quit
+		case "tab", "1", "2":
+			next := tabConversation
+			if msg.String() == "2" || (msg.String() == "tab" && v.tab == tabConversation) {
+				next = tabDiff
+			}
+			if next == tabDiff && v.diff == nil && !v.diffLoading {
+				v.diffLoading, v.diffErr = true, nil
+				cmds = append(cmds, fetchDiff(v.pr.Number))
+			}
+			v.tab = next
+			v.refresh()
+			v.viewport.GotoTop()
+			return v, tea.Batch(cmds...)
+		}
+
+	case tea.WindowSizeMsg:
+		headerHeight := lipgloss.Height(v.headerView())
+		footerHeight := lipgloss.Height(v.footerView())
+		if !v.ready {
+			v.viewport = viewport.New(msg.Width, msg.Height-headerHeight-footerHeight)
+			v.viewport.YPosition = headerHeight
+			v.ready = true
+		} else {
+			v.viewport.Width = msg.Width
+			v.viewport.Height = msg.Height - headerHeight - footerHeight
+		}
+		v.refresh()
+
+	case detailsMsg:
+		if v.detailsErr = msg.err; msg.err == nil {
+			v.details = &msg.details
+		}
+		v.refresh()
+
+	case diffMsg:
+		v.diffLoading = false
+		if v.diffErr = msg.err; msg.err == nil {
+			v.diff = &msg.diff
+		}
+		v.refresh()
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	cmds = append(cmds, cmd)
+	return v, tea.Batch(cmds...)
+}
+
+// refresh renders the active tab into the viewport.
+func (v *prViewer) refresh() {
+	if !v.ready {
+		return
+	}
+	switch {
+	case v.tab == tabDiff && v.diffErr != nil:
+		v.viewport.SetContent(redStyle.Render(v.diffErr.Error()))
+	case v.tab == tabDiff && v.diff == nil:
+		v.viewport.SetContent(grayStyle.Render("Loading diff..."))
+	case v.tab == tabDiff:
+		v.viewport.SetContent(renderDiff(*v.diff))
+	case v.detailsErr != nil:
+		v.viewport.SetContent(redStyle.Render(v.detailsErr.Error()))
+	case v.details == nil:
+		v.viewport.SetContent(grayStyle.Render("Loading..."))
+	default:
+		v.viewport.SetContent(renderConversation(*v.details, v.viewport.Width))
+	}
+}
+
+func (v *prViewer) headerView() string {
+	tabs := []string{"1 Conversation", "2 Diff"}
+	for i, t := range tabs {
+		if i == v.tab {
+			tabs[i] = underlineStyle.Render(t)
+		} else {
+			tabs[i] = grayStyle.Render(t)
+		}
+	}
+	return fmt.Sprintf("%s  %s\n%s\n", styleID(v.pr), v.pr.Title, strings.Join(tabs, "  "))
+}
+
+func (v *prViewer) footerView() string {
+	return grayStyle.Render(fmt.Sprintf("↑/↓ scroll · tab switch · q quit · %3.f%%", v.viewport.ScrollPercent()*100))
+}
+
+func (v *prViewer) View() string {
+	if !v.ready {
+		return grayStyle.Render("Loading...")
+	}
+	return v.headerView() + v.viewport.View() + "\n" + v.footerView()
+}
+
+// renderConversation lays out the PR body followed by its comments,
+// wrapped to width.
+func renderConversation(d prDetails, width int) string {
+	var b strings.Builder
+	b.WriteString(grayStyle.Render(fmt.Sprintf("opened by %s · %s", d.Author.Login, d.URL)))
+	b.WriteString("\n\n")
+	if body := strings.TrimSpace(d.Body); body != "" {
+		b.WriteString(renderMarkdown(body, width))
+	} else {
+		b.WriteString(grayStyle.Render("No description provided."))
+	}
+	b.WriteString("\n")
+
+	for _, c := range d.Comments {
+		b.WriteString("\n")
+		b.WriteString(cyanStyle.Render(c.Author.Login) + " " + grayStyle.Render(displayTime(c.CreatedAt)))
+		b.WriteString("\n")
+		b.WriteString(renderMarkdown(strings.TrimSpace(c.Body), width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// markdownStyle is the glamour style for the active theme, without colors
+// when they are off.
+func markdownStyle() string {
+	switch {
+	case lipgloss.ColorProfile() == termenv.Ascii:
+		return styles.NoTTYStyle
+	case activeTheme.markdown != "":
+		return activeTheme.markdown
+	case lipgloss.HasDarkBackground():
+		return styles.DarkStyle
+	default:
+		return styles.LightStyle
+	}
+}
+
+// renderMarkdown renders md with glamour, wrapped to width. Markdown glamour
+// can't render is shown as written.
+func renderMarkdown(md string, width int) string {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(markdownStyle()),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return lipgloss.NewStyle().Width(width).Render(md)
+	}
+	out, err := r.Render(md)
+	if err != nil {
+		return lipgloss.NewStyle().Width(width).Render(md)
+	}
+	return strings.Trim(out, "\n")
+}
+
+// renderDiff colors added and removed lines of a unified diff.
+func renderDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff "):
+			lines[i] = lipgloss.NewStyle().Bold(true).Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = greenStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = redStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = cyanStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
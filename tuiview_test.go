@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	md := "# Fix login\n\nThe form **forgot** the user after a reload, so this keeps the session around.\n\n```go\nfmt.Println(1)\n```"
+	got := renderMarkdown(md, 30)
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("renderMarkdown() = %q, want no escape sequences without colors", got)
+	}
+	for _, want := range []string{"Fix login", "forgot", "fmt.Println(1)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderMarkdown() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "```") {
+		t.Errorf("renderMarkdown() = %q, want the code fence rendered", got)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if w := runewidth.StringWidth(line); w > 30 {
+			t.Errorf("renderMarkdown() line %q is %d wide, want at most 30", line, w)
+		}
+	}
+}
+
+func TestMarkdownStyle(t *testing.T) {
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		applyTheme(themes[defaultTheme])
+	})
+	tests := []struct {
+		theme   string
+		profile termenv.Profile
+		want    string
+	}{
+		{"dracula", termenv.Ascii, styles.NoTTYStyle},
+		{"dracula", termenv.TrueColor, styles.DraculaStyle},
+		{"mono", termenv.TrueColor, styles.NoTTYStyle},
+	}
+	for _, tt := range tests {
+		lipgloss.SetColorProfile(tt.profile)
+		applyTheme(themes[tt.theme])
+		if got := markdownStyle(); got != tt.want {
+			t.Errorf("markdownStyle() with %s = %q, want %q", tt.theme, got, tt.want)
+		}
+	}
+}
+
+func TestViewerKeepsTabErrorsApart(t *testing.T) {
+	v := &prViewer{pr: PullRequest{Number: 1}}
+	v.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	v.Update(detailsMsg{details: prDetails{Body: "The body"}})
+	v.tab = tabDiff
+	v.Update(diffMsg{err: errors.New("diff failed")})
+
+	if v.diff != nil {
+		t.Errorf("diff = %q after a failed fetch, want nil", *v.diff)
+	}
+	if got := v.viewport.View(); !strings.Contains(got, "diff failed") {
+		t.Errorf("diff tab = %q, want the diff error", got)
+	}
+	v.tab = tabConversation
+	v.refresh()
+	if got := v.viewport.View(); strings.Contains(got, "diff failed") || !strings.Contains(got, "The body") {
+		t.Errorf("conversation tab = %q, want the body without the diff error", got)
+	}
+}
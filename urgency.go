@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// urgencyWeights controls how much each signal contributes to the urgency
+// score. They are read from the "urgency" section of the config file.
+type urgencyWeights struct {
+	// ReviewRequested is added when my review is requested directly
+	ReviewRequested float64 `yaml:"review_requested"`
+	// ChangesRequested is added when the review decision is CHANGES_REQUESTED
+	ChangesRequested float64 `yaml:"changes_requested"`
+	// AgePerDay is added for every day since the PR was opened
+	AgePerDay float64 `yaml:"age_per_day"`
+}
+
+var defaultUrgencyWeights = urgencyWeights{
+	ReviewRequested:  10,
+	ChangesRequested: 5,
+	AgePerDay:        0.5,
+}
+
+// urgencyFields are the gh pr list fields the urgency score is computed from.
+var urgencyFields = []string{"reviewDecision", "reviewRequests"}
+
+// urgencyColumn shows the urgency score of a PR.
+var urgencyColumn = column{
+	header: "URGENCY",
+	value:  func(pr PullRequest) string { return fmt.Sprintf("%.1f", pr.urgency) },
+	style:  func(PullRequest) lipgloss.Style { return yellowStyle },
+	fields: urgencyFields,
+	legend: func() []legendEntry {
+		return []legendEntry{{"12.5", yellowStyle, "urgency score, higher is more urgent"}}
+	},
+}
+
+type reviewRequest struct {
+	// Login is empty for team review requests
+	Login string `json:"login"`
+}
+
+// getViewerLogin returns the login of the authenticated user, or "" if it
+// cannot be determined. It is looked up once per process.
+var getViewerLogin = sync.OnceValue(func() string {
+	stdout, _, err := ghExec("api", "user", "-q", ".login")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(stdout.String())
+})
+
+// scoreUrgency sets the urgency of every PR from the review signals for
+// login and the PR's age.
+func scoreUrgency(prs []PullRequest, login string, w urgencyWeights, now time.Time) {
+	for i := range prs {
+		pr := &prs[i]
+		var score float64
+		// A missing creation time must not count as two thousand years of age
+		if !pr.CreatedAt.IsZero() {
+			score += w.AgePerDay * now.Sub(pr.CreatedAt).Hours() / 24
+		}
+		if login != "" && isReviewRequestedFrom(*pr, login) {
+			score += w.ReviewRequested
+		}
+		if pr.ReviewDecision == "CHANGES_REQUESTED" {
+			score += w.ChangesRequested
+		}
+		pr.urgency = score
+	}
+}
+
+func isReviewRequestedFrom(pr PullRequest, login string) bool {
+	for _, r := range pr.ReviewRequests {
+		if strings.EqualFold(r.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortByUrgency orders PRs from most to least urgent, keeping gh's order
+// for ties. PRs without a creation time are sorted last.
+func sortByUrgency(prs []PullRequest) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		if zi, zj := prs[i].CreatedAt.IsZero(), prs[j].CreatedAt.IsZero(); zi != zj {
+			return zj
+		}
+		return prs[i].urgency > prs[j].urgency
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScoreUrgencyWithoutCreatedAt(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1, CreatedAt: now.Add(-48 * time.Hour)},
+		{Number: 2},
+	}
+	scoreUrgency(prs, "", defaultUrgencyWeights, now)
+	if prs[0].urgency != 1 {
+		t.Errorf("urgency of a PR opened two days ago = %v, want 1", prs[0].urgency)
+	}
+	if prs[1].urgency != 0 {
+		t.Errorf("urgency of a PR without createdAt = %v, want 0", prs[1].urgency)
+	}
+}
+
+func TestSortByUrgencyWithoutCreatedAt(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1, urgency: 5},
+		{Number: 2, urgency: 1, CreatedAt: day},
+		{Number: 3, urgency: 3, CreatedAt: day},
+		{Number: 4},
+	}
+	sortByUrgency(prs)
+	got := make([]int, len(prs))
+	for i, pr := range prs {
+		got[i] = pr.Number
+	}
+	if want := []int{3, 2, 1, 4}; !slices.Equal(got, want) {
+		t.Errorf("sortByUrgency() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// flagSwitch is a boolean flag by name, for reporting combinations.
+type flagSwitch struct {
+	name string
+	on   bool
+}
+
+// compatibleActions are action flags that work together: the PR page and
+// the comparison can be opened at once, and --summary prints JSON with --json.
+var compatibleActions = [][]string{{"view", "compare"}, {"summary", "json"}}
+
+// validateCombinations reports flags that contradict each other or would be
+// silently ignored together. Values of single flags are checked by
+// parseFlags.
+func validateCombinations(f flags) error {
+	// Actions replace the checkout with something else, so at most one of
+	// them can be given, except for the compatibleActions
+	var actions []string
+	for _, a := range []flagSwitch{
+		{"web", f.web}, {"view", f.view}, {"compare", f.compare}, {"json", f.json},
+		{"tui-view", f.tuiView}, {"difftool", f.difftool}, {"approve", f.approve},
+		{"merge", f.merge}, {"reopen", f.reopen}, {"session", f.session},
+		{"conflict-check", f.conflictCheck}, {"live-mergeable", f.liveMergeable},
+		{"ensure-pr", f.ensurePR}, {"summary", f.summary}, {"stash-pop", f.stashPop},
+		{"interactive", f.interactive}, {"copy", f.copy != ""}, {"checks", f.checks},
+		{"print", f.print},
+	} {
+		if a.on {
+			actions = append(actions, a.name)
+		}
+	}
+	if len(actions) > 1 && !slices.ContainsFunc(compatibleActions, func(group []string) bool {
+		return !slices.ContainsFunc(actions, func(a string) bool { return !slices.Contains(group, a) })
+	}) {
+		return fmt.Errorf("`--%s` and `--%s` cannot be combined", actions[0], actions[1])
+	}
+
+	// These only change how a PR is checked out, which --web does too and
+	// --interactive offers
+	for _, c := range []flagSwitch{
+		{"stash", f.stash}, {"branch-template", f.branchTemplate != nil}, {"protocol", f.protocol != ""},
+		{"worktree", f.worktree != ""}, {"force", f.force}, {"recreate", f.recreate},
+	} {
+		if c.on && len(actions) > 0 && actions[0] != "web" && actions[0] != "interactive" {
+			return fmt.Errorf("`--%s` only applies to checkout and cannot be combined with `--%s`", c.name, actions[0])
+		}
+	}
+
+	// --dry-run covers the checkout and opening the PR and its issues, but
+	// nothing that changes the clone beforehand
+	if f.dryRun {
+		if i := slices.IndexFunc(actions, func(a string) bool { return a != "web" && a != "view" }); i >= 0 {
+			return fmt.Errorf("`--dry-run` only applies to checkout, `--web` and `--view` and cannot be combined with `--%s`", actions[i])
+		}
+		for _, c := range []flagSwitch{
+			{"stash", f.stash}, {"protocol", f.protocol != ""}, {"worktree", f.worktree != ""}, {"recreate", f.recreate},
+		} {
+			if c.on {
+				return fmt.Errorf("`--%s` changes the clone and cannot be combined with `--dry-run`", c.name)
+			}
+		}
+	}
+
+	// These look at the local clone, which isn't the --repo repository
+	if f.repo != "" {
+		for _, c := range []flagSwitch{{"ensure-pr", f.ensurePR}, {"protocol", f.protocol != ""}} {
+			if c.on {
+				return fmt.Errorf("`--%s` works on the current repository and cannot be combined with `--repo`", c.name)
+			}
+		}
+	}
+
+	if host, _, _ := strings.Cut(f.repo, "/"); f.hostname != "" && strings.Count(f.repo, "/") == 2 && host != f.hostname {
+		return fmt.Errorf("`--repo` names the host %s, which isn't the `--hostname` %s", host, f.hostname)
+	}
+
+	if f.stash && f.worktree != "" {
+		return errors.New("`--worktree` leaves the current working tree alone, so `--stash` is not needed")
+	}
+
+	if f.jsonPretty && f.jsonCompact {
+		return errors.New("specify only one of `--json-pretty` or `--json-compact`")
+	}
+	if (f.jsonPretty || f.jsonCompact) && !f.json {
+		return errors.New("`--json-pretty` and `--json-compact` require `--json`")
+	}
+	if (f.mergeMethod != "" || f.deleteBranch) && !f.merge {
+		return errors.New("`--merge-commit`, `--squash`, `--rebase` and `--delete-branch` require `--merge`")
+	}
+	if f.reopen && f.state != "closed" {
+		return errors.New("`--reopen` requires `--state closed`")
+	}
+	if f.projectStatus != "" && f.project == 0 {
+		return errors.New("`--project-status` requires `--project`")
+	}
+	if f.multi && !f.approve {
+		return errMultiWithoutAction
+	}
+	if f.multi && f.plain {
+		return errors.New("`--multi` selects PRs in the picker and cannot be combined with `--plain`")
+	}
+	if f.print && f.plain {
+		return errors.New("`--print` prints the PR chosen in the picker and cannot be combined with `--plain`")
+	}
+	if f.multi && f.query != "" {
+		return errors.New("`--multi` selects PRs in the picker and cannot be combined with a PR argument")
+	}
+	if f.minFiles >= 0 && f.maxFiles >= 0 && f.minFiles > f.maxFiles {
+		return errors.New("`--min-files` cannot be greater than `--max-files`")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestValidateCombinations(t *testing.T) {
+	tests := []struct {
+		name string
+		f    flags
+		want string
+	}{
+		{name: "no flags", f: flags{state: "open"}},
+		{name: "view and compare", f: flags{view: true, compare: true}},
+		{name: "summary and json", f: flags{summary: true, json: true}},
+		{name: "stash with web", f: flags{web: true, stash: true}},
+		{name: "worktree with interactive", f: flags{interactive: true, worktree: "../wt"}},
+		{name: "dry-run with view", f: flags{view: true, dryRun: true}},
+		{name: "json and web", f: flags{web: true, json: true}, want: "`--web` and `--json` cannot be combined"},
+		{name: "approve and merge", f: flags{approve: true, merge: true}, want: "`--approve` and `--merge` cannot be combined"},
+		{name: "checks and web", f: flags{web: true, checks: true}, want: "`--web` and `--checks` cannot be combined"},
+		{name: "print and json", f: flags{json: true, print: true}, want: "`--json` and `--print` cannot be combined"},
+		{name: "print with plain", f: flags{print: true, plain: true}, want: "`--print` prints the PR chosen in the picker"},
+		{name: "copy and view", f: flags{view: true, copy: "url"}, want: "`--view` and `--copy` cannot be combined"},
+		{name: "stash with json", f: flags{json: true, stash: true}, want: "`--stash` only applies to checkout"},
+		{name: "worktree with approve", f: flags{approve: true, worktree: "wt"}, want: "`--worktree` only applies to checkout"},
+		{name: "dry-run with merge", f: flags{merge: true, dryRun: true}, want: "cannot be combined with `--merge`"},
+		{name: "force with web", f: flags{web: true, force: true}},
+		{name: "recreate with view", f: flags{view: true, recreate: true}, want: "`--recreate` only applies to checkout"},
+		{name: "dry-run with recreate", f: flags{recreate: true, dryRun: true}, want: "`--recreate` changes the clone"},
+		{name: "dry-run with stash", f: flags{stash: true, dryRun: true}, want: "`--stash` changes the clone"},
+		{name: "repo with ensure-pr", f: flags{ensurePR: true, repo: "cli/cli"}, want: "`--ensure-pr` works on the current repository"},
+		{name: "repo on the hostname", f: flags{repo: "ghe.example.com/owner/repo", hostname: "ghe.example.com"}},
+		{name: "repo on another host", f: flags{repo: "github.com/owner/repo", hostname: "ghe.example.com"}, want: "names the host github.com"},
+		{name: "stash with worktree", f: flags{stash: true, worktree: "wt"}, want: "`--stash` is not needed"},
+		{name: "json-pretty and json-compact", f: flags{json: true, jsonPretty: true, jsonCompact: true}, want: "only one of"},
+		{name: "json-pretty without json", f: flags{jsonPretty: true}, want: "require `--json`"},
+		{name: "squash without merge", f: flags{mergeMethod: "squash"}, want: "require `--merge`"},
+		{name: "reopen without closed", f: flags{reopen: true, state: "open"}, want: "`--reopen` requires `--state closed`"},
+		{name: "project-status without project", f: flags{projectStatus: "Todo"}, want: "`--project-status` requires `--project`"},
+		{name: "multi without approve", f: flags{multi: true}, want: errMultiWithoutAction.Error()},
+		{name: "multi with plain", f: flags{multi: true, approve: true, plain: true}, want: "cannot be combined with `--plain`"},
+		{name: "multi with a PR argument", f: flags{multi: true, approve: true, query: "12"}, want: "cannot be combined with a PR argument"},
+		{name: "min-files above max-files", f: flags{minFiles: 10, maxFiles: 2}, want: "`--min-files` cannot be greater"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCombinations(tt.f)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("validateCombinations() = %v, want nil", err)
+			case tt.want != "" && err == nil:
+				t.Errorf("validateCombinations() = nil, want %q", tt.want)
+			case tt.want != "" && !strings.Contains(err.Error(), tt.want):
+				t.Errorf("validateCombinations() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// actionFlags set each action flag, in the order validateCombinations
+// reports them.
+var actionFlags = []struct {
+	name string
+	set  func(*flags)
+}{
+	{"web", func(f *flags) { f.web = true }},
+	{"view", func(f *flags) { f.view = true }},
+	{"compare", func(f *flags) { f.compare = true }},
+	{"json", func(f *flags) { f.json = true }},
+	{"tui-view", func(f *flags) { f.tuiView = true }},
+	{"difftool", func(f *flags) { f.difftool = true }},
+	{"approve", func(f *flags) { f.approve = true }},
+	{"merge", func(f *flags) { f.merge = true }},
+	{"reopen", func(f *flags) { f.reopen = true }},
+	{"session", func(f *flags) { f.session = true }},
+	{"conflict-check", func(f *flags) { f.conflictCheck = true }},
+	{"live-mergeable", func(f *flags) { f.liveMergeable = true }},
+	{"ensure-pr", func(f *flags) { f.ensurePR = true }},
+	{"summary", func(f *flags) { f.summary = true }},
+	{"stash-pop", func(f *flags) { f.stashPop = true }},
+	{"interactive", func(f *flags) { f.interactive = true }},
+	{"copy", func(f *flags) { f.copy = "url" }},
+	{"checks", func(f *flags) { f.checks = true }},
+	{"print", func(f *flags) { f.print = true }},
+}
+
+// checkoutFlags set each flag that only changes how a PR is checked out.
+var checkoutFlags = []struct {
+	name string
+	set  func(*flags)
+}{
+	{"stash", func(f *flags) { f.stash = true }},
+	{"branch-template", func(f *flags) { f.branchTemplate = template.Must(template.New("").Parse("pr-{{.Number}}")) }},
+	{"protocol", func(f *flags) { f.protocol = "ssh" }},
+	{"worktree", func(f *flags) { f.worktree = "../wt" }},
+	{"force", func(f *flags) { f.force = true }},
+	{"recreate", func(f *flags) { f.recreate = true }},
+}
+
+func TestValidateCombinationsPairs(t *testing.T) {
+	compatible := map[string]bool{"view+compare": true, "json+summary": true}
+	type pair struct {
+		name string
+		f    flags
+		want string
+	}
+	var pairs []pair
+	// Every two actions, in the order they are reported
+	for i, a := range actionFlags {
+		for _, b := range actionFlags[i+1:] {
+			f := flags{state: "closed"}
+			a.set(&f)
+			b.set(&f)
+			want := fmt.Sprintf("`--%s` and `--%s` cannot be combined", a.name, b.name)
+			if compatible[a.name+"+"+b.name] || compatible[b.name+"+"+a.name] {
+				want = ""
+			}
+			pairs = append(pairs, pair{a.name + " and " + b.name, f, want})
+		}
+	}
+	// Checkout flags with every action but the ones that check out
+	for _, c := range checkoutFlags {
+		for _, a := range actionFlags {
+			f := flags{state: "closed"}
+			c.set(&f)
+			a.set(&f)
+			want := fmt.Sprintf("`--%s` only applies to checkout and cannot be combined with `--%s`", c.name, a.name)
+			if a.name == "web" || a.name == "interactive" {
+				want = ""
+			}
+			pairs = append(pairs, pair{c.name + " with " + a.name, f, want})
+		}
+	}
+	// --dry-run with every action and every flag that changes the clone
+	for _, a := range actionFlags {
+		f := flags{state: "closed", dryRun: true}
+		a.set(&f)
+		want := fmt.Sprintf("cannot be combined with `--%s`", a.name)
+		if a.name == "web" || a.name == "view" {
+			want = ""
+		}
+		pairs = append(pairs, pair{"dry-run with " + a.name, f, want})
+	}
+	for _, c := range checkoutFlags {
+		f := flags{dryRun: true}
+		c.set(&f)
+		want := fmt.Sprintf("`--%s` changes the clone and cannot be combined with `--dry-run`", c.name)
+		if c.name == "branch-template" || c.name == "force" {
+			want = ""
+		}
+		pairs = append(pairs, pair{"dry-run with " + c.name, f, want})
+	}
+	// --repo with the flags that need the local clone
+	for _, c := range []struct {
+		name string
+		set  func(*flags)
+	}{
+		{"ensure-pr", func(f *flags) { f.ensurePR = true }},
+		{"protocol", func(f *flags) { f.protocol = "ssh" }},
+	} {
+		f := flags{repo: "cli/cli"}
+		c.set(&f)
+		pairs = append(pairs, pair{"repo with " + c.name, f, fmt.Sprintf("`--%s` works on the current repository", c.name)})
+	}
+
+	for _, tt := range pairs {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCombinations(tt.f)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("validateCombinations() = %v, want nil", err)
+			case tt.want != "" && err == nil:
+				t.Errorf("validateCombinations() = nil, want %q", tt.want)
+			case tt.want != "" && !strings.Contains(err.Error(), tt.want):
+				t.Errorf("validateCombinations() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
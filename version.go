@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Build metadata, set with e.g.
+// -ldflags "-X main.version=v1.2.3 -X main.commit=abc1234 -X main.date=2026-01-02".
+// Whatever is left empty is taken from the build info Go embeds.
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// versionString describes the build, e.g.
+// "gh po v1.2.3 (commit abc1234, built 2026-01-02T15:04:05Z)".
+func versionString() string {
+	v, c, d := version, commit, date
+	dirty := false
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" && info.Main.Version != "" {
+			v = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && c == "":
+				c = s.Value
+			case s.Key == "vcs.time" && d == "":
+				d = s.Value
+			case s.Key == "vcs.modified":
+				dirty = s.Value == "true" && commit == ""
+			}
+		}
+	}
+	if v == "" {
+		v = "(devel)"
+	}
+	if len(c) > 12 {
+		c = c[:12]
+	}
+	if dirty {
+		c += "-dirty"
+	}
+
+	s := "gh po " + v
+	switch {
+	case c != "" && d != "":
+		s += fmt.Sprintf(" (commit %s, built %s)", c, d)
+	case c != "":
+		s += fmt.Sprintf(" (commit %s)", c)
+	case d != "":
+		s += fmt.Sprintf(" (built %s)", d)
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// longWait is the wait for a first review from which the WAITING column
+// turns red.
+const longWait = 3 * 24 * time.Hour
+
+// prReview is one entry of a PR's reviews.
+type prReview struct {
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// waitingFields are the gh pr list fields the WAITING column and
+// --sort waiting need.
+var waitingFields = []string{"reviews"}
+
+// waitingColumn shows how long a PR without any review has been open.
+var waitingColumn = column{
+	header: "WAITING",
+	value: func(pr PullRequest) string {
+		if len(pr.Reviews) > 0 || pr.CreatedAt.IsZero() {
+			return "-"
+		}
+		return shortDuration(waitingFor(pr, time.Now()))
+	},
+	style: func(pr PullRequest) lipgloss.Style {
+		if len(pr.Reviews) == 0 && !pr.CreatedAt.IsZero() && waitingFor(pr, time.Now()) >= longWait {
+			return redStyle
+		}
+		return grayStyle
+	},
+	fields: waitingFields,
+	legend: func() []legendEntry {
+		return []legendEntry{
+			{"5h", grayStyle, "open for 5 hours without any review"},
+			{"4d", redStyle, "waiting for a first review for " + shortDuration(longWait) + " or more"},
+			{"-", grayStyle, "already reviewed, or opened at an unknown time"},
+		}
+	},
+}
+
+// waitingFor is how long pr has been open, the time it has waited for a
+// first review if it has none. It is zero without a creation time.
+func waitingFor(pr PullRequest, now time.Time) time.Duration {
+	if pr.CreatedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(pr.CreatedAt)
+}
+
+// sortByWaiting puts PRs without reviews first, longest waiting first.
+// Reviewed PRs follow in their original order, and PRs without a creation
+// time are sorted last.
+func sortByWaiting(prs []PullRequest) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		if zi, zj := prs[i].CreatedAt.IsZero(), prs[j].CreatedAt.IsZero(); zi != zj {
+			return zj
+		}
+		iWaiting, jWaiting := len(prs[i].Reviews) == 0, len(prs[j].Reviews) == 0
+		if iWaiting != jWaiting {
+			return iWaiting
+		}
+		return iWaiting && prs[i].CreatedAt.Before(prs[j].CreatedAt)
+	})
+}
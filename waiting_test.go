@@ -0,0 +1,38 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWaitingWithoutCreatedAt(t *testing.T) {
+	pr := PullRequest{Number: 1}
+	if got := waitingFor(pr, time.Now()); got != 0 {
+		t.Errorf("waitingFor() without createdAt = %v, want 0", got)
+	}
+	if got := waitingColumn.value(pr); got != "-" {
+		t.Errorf("WAITING without createdAt = %q, want %q", got, "-")
+	}
+	if got := waitingColumn.style(pr); got.GetForeground() != grayStyle.GetForeground() {
+		t.Errorf("WAITING without createdAt is styled %v, want gray", got.GetForeground())
+	}
+}
+
+func TestSortByWaitingWithoutCreatedAt(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prs := []PullRequest{
+		{Number: 1},
+		{Number: 2, CreatedAt: day.Add(time.Hour)},
+		{Number: 3, CreatedAt: day, Reviews: []prReview{{SubmittedAt: day}}},
+		{Number: 4, CreatedAt: day},
+	}
+	sortByWaiting(prs)
+	got := make([]int, len(prs))
+	for i, pr := range prs {
+		got[i] = pr.Number
+	}
+	if want := []int{4, 2, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("sortByWaiting() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkoutInWorktree creates a worktree at path and runs gh pr checkout in
+// it, into a local branch called branch if it is not empty. The PR is set up
+// as gh would in the current working tree, fork remotes included, while the
+// current working tree stays as it is.
+func checkoutInWorktree(pr PullRequest, branch, path string) error {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid worktree path %q: %w", path, err)
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists; choose a path for a new worktree", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	printSelected(pr)
+
+	var stdoutStr, stderrStr string
+	var execErr error
+
+	runWithProgress("Checking out PR into a new worktree...", func() {
+		// Detached, so that gh can create or reuse the PR's branch in it
+		if _, stderr, err := runGit("worktree", "add", "--quiet", "--detach", dir); err != nil {
+			stderrStr = stderr
+			execErr = fmt.Errorf("failed to create worktree at %s", dir)
+			return
+		}
+		args := checkoutArgs(pr, branch)
+		var stdout, stderr strings.Builder
+		cmd, run := ghCommand(checkoutTimeout, args...)
+		cmd.Dir = dir
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := run(); err != nil {
+			execErr = fmt.Errorf("failed to checkout PR #%d: %w", pr.Number, err)
+			// Don't leave a worktree behind that has nothing checked out
+			_, _, _ = runGit("worktree", "remove", "--force", dir)
+		}
+		stdoutStr = stdout.String()
+		stderrStr = stderr.String()
+	})
+
+	if stdoutStr != "" {
+		fmt.Print(stdoutStr)
+	}
+	if stderrStr != "" {
+		fmt.Print(stderrStr)
+	}
+	if execErr != nil {
+		return execErr
+	}
+
+	fmt.Printf("\n%s Checked out into %s\n", greenStyle.Render("✓"), dir)
+	return nil
+}